@@ -0,0 +1,40 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=cep
+// +kubebuilder:printcolumn:name="Matched",type=integer,JSONPath=`.status.matchedResources`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Actions",type=integer,JSONPath=`.status.actionsPerformed`
+// +kubebuilder:printcolumn:name="Paused",type=integer,JSONPath=`.status.pausedResources`
+// +kubebuilder:printcolumn:name="Savings",type=string,JSONPath=`.status.estimatedSavings`
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=`.spec.priority`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterEnforcementPolicy is a cluster-wide EnforcementPolicy for platform
+// defaults. An EnforcementPolicy overrides it for workloads in the
+// EnforcementPolicy's own namespace.
+type ClusterEnforcementPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EnforcementPolicySpec   `json:"spec,omitempty"`
+	Status EnforcementPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterEnforcementPolicyList contains a list of ClusterEnforcementPolicy
+type ClusterEnforcementPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterEnforcementPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterEnforcementPolicy{}, &ClusterEnforcementPolicyList{})
+}
@@ -22,6 +22,15 @@ type EnforcementPolicySpec struct {
 	// Schedule defines when this policy is active
 	// +optional
 	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+
+	// Reactivation restores workloads paused by this policy automatically
+	// +optional
+	Reactivation *ReactivationSpec `json:"reactivation,omitempty"`
+
+	// Priority orders policies of the same precedence tier that match the
+	// same workload; the highest priority owns the enforcement decision
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // ScopeSpec defines the scope of resources to evaluate
@@ -29,19 +38,51 @@ type ScopeSpec struct {
 	// Namespaces defines namespace filters
 	Namespaces NamespaceFilter `json:"namespaces"`
 
+	// NamespaceSelector selects namespaces by their labels; namespaces must
+	// also match Namespaces.Include
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
 	// Labels defines label-based filters
 	// +optional
 	Labels *LabelFilter `json:"labels,omitempty"`
+
+	// Kinds defines which workload kinds are evaluated (defaults to Deployment)
+	// +optional
+	Kinds []WorkloadKind `json:"kinds,omitempty"`
+
+	// Expression is a CEL expression that must be true for a workload to be
+	// in scope. Variables: object (the workload) and namespaceObject (its
+	// Namespace).
+	// +optional
+	Expression string `json:"expression,omitempty"`
 }
 
+// WorkloadKind defines a workload kind that can be enforced
+// +kubebuilder:validation:Enum=Deployment;StatefulSet;CronJob
+type WorkloadKind string
+
+const (
+	WorkloadKindDeployment  WorkloadKind = "Deployment"
+	WorkloadKindStatefulSet WorkloadKind = "StatefulSet"
+	WorkloadKindCronJob     WorkloadKind = "CronJob"
+)
+
 // NamespaceFilter defines namespace inclusion/exclusion
 type NamespaceFilter struct {
-	// Include is a list of namespace patterns to include (supports wildcards)
+	// Include is a list of namespace patterns to include: globs such as
+	// dev-* or team-?-dev, or regexes prefixed with re:
 	Include []string `json:"include"`
 
-	// Exclude is a list of namespace patterns to exclude (supports wildcards)
+	// Exclude is a list of namespace patterns to exclude, in the same format
+	// as Include
 	// +optional
 	Exclude []string `json:"exclude,omitempty"`
+
+	// RequireOptIn limits the policy to namespaces annotated
+	// finops.io/opt-in=true
+	// +optional
+	RequireOptIn bool `json:"requireOptIn,omitempty"`
 }
 
 // LabelFilter defines label-based filtering
@@ -53,6 +94,11 @@ type LabelFilter struct {
 	// Exclude defines labels that exclude resources
 	// +optional
 	Exclude map[string]string `json:"exclude,omitempty"`
+
+	// MatchExpressions are set-based label requirements that must all hold,
+	// e.g. team In (payments, billing)
+	// +optional
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
 }
 
 // ConditionsSpec defines idle detection criteria
@@ -70,59 +116,102 @@ type ConditionsSpec struct {
 	// UtilizationThreshold defines resource utilization thresholds
 	// +optional
 	UtilizationThreshold *UtilizationThresholdSpec `json:"utilizationThreshold,omitempty"`
+
+	// Expression is a CEL expression that must be true for a workload to be
+	// idle. Variables: object (the workload), namespaceObject (its Namespace)
+	// and cost (hourlyCost, dailyCost, totalCost, monthlyCost and labels).
+	// +optional
+	Expression string `json:"expression,omitempty"`
 }
 
 // TrafficThresholdSpec defines traffic-based idle criteria
 type TrafficThresholdSpec struct {
 	// RequestsPerMinute is the maximum requests/min to consider idle
 	RequestsPerMinute int `json:"requestsPerMinute"`
+
+	// Query is a PromQL template returning requests per minute for a workload.
+	// Available fields: {{.Namespace}}, {{.Name}}, {{.Kind}} and {{.Window}}
+	// (the idle window as a range selector). Defaults to Istio request rates.
+	// +optional
+	Query string `json:"query,omitempty"`
 }
 
 // UtilizationThresholdSpec defines resource utilization thresholds
 type UtilizationThresholdSpec struct {
 	// CPU is the maximum CPU utilization percentage to consider idle
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?%?$`
 	// +optional
 	CPU string `json:"cpu,omitempty"`
 
 	// Memory is the maximum memory utilization percentage to consider idle
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?%?$`
 	// +optional
 	Memory string `json:"memory,omitempty"`
 }
 
 // ActionsSpec defines enforcement actions
 type ActionsSpec struct {
-	// Type is the action type (only "scaleToZero" supported)
+	// Type is the action type ("scaleToZero" for Deployments/StatefulSets,
+	// "suspend" for CronJobs)
 	Type ActionType `json:"type"`
 
 	// Notify defines notification method
 	Notify NotifyType `json:"notify"`
 
+	// Notifiers lists additional notification methods, sent alongside Notify
+	// +optional
+	Notifiers []NotifyType `json:"notifiers,omitempty"`
+
 	// ReactivationAllowed enables user-initiated reactivation
 	ReactivationAllowed bool `json:"reactivationAllowed"`
+
+	// WarningPeriod, when set, turns the first match into a warning
+	// notification and delays the action until the period has elapsed
+	// +optional
+	WarningPeriod metav1.Duration `json:"warningPeriod,omitempty"`
+
+	// Digest replaces per-action pause notifications with a daily or weekly
+	// summary sent to the same notifiers
+	// +optional
+	Digest DigestFrequency `json:"digest,omitempty"`
 }
 
 // ActionType defines the type of enforcement action
-// +kubebuilder:validation:Enum=scaleToZero
+// +kubebuilder:validation:Enum=scaleToZero;suspend
 type ActionType string
 
 const (
 	ActionTypeScaleToZero ActionType = "scaleToZero"
+	ActionTypeSuspend     ActionType = "suspend"
 )
 
 // NotifyType defines notification method
-// +kubebuilder:validation:Enum=slack;none
+// +kubebuilder:validation:Enum=slack;teams;email;webhook;none
 type NotifyType string
 
 const (
-	NotifyTypeSlack NotifyType = "slack"
-	NotifyTypeNone  NotifyType = "none"
+	NotifyTypeSlack   NotifyType = "slack"
+	NotifyTypeTeams   NotifyType = "teams"
+	NotifyTypeEmail   NotifyType = "email"
+	NotifyTypeWebhook NotifyType = "webhook"
+	NotifyTypeNone    NotifyType = "none"
+)
+
+// DigestFrequency defines how often a notification digest is sent
+// +kubebuilder:validation:Enum=daily;weekly
+type DigestFrequency string
+
+const (
+	DigestDaily  DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
 )
 
 // EnforcementSpec defines enforcement constraints
 type EnforcementSpec struct {
-	// DryRun enables dry-run mode (no actual enforcement)
+	// DryRun enables dry-run mode (no actual enforcement). The admission
+	// webhook defaults it to true on new policies; unset enforces.
 	// +optional
-	DryRun bool `json:"dryRun,omitempty"`
+	DryRun *bool `json:"dryRun,omitempty"`
 
 	// MaxActionsPerRun limits actions per reconciliation
 	// +optional
@@ -133,13 +222,65 @@ type EnforcementSpec struct {
 	CooldownWindow metav1.Duration `json:"cooldownWindow,omitempty"`
 }
 
+// IsDryRun reports whether actions are only simulated; an unset DryRun
+// enforces, as policies created before the webhook defaulted it did
+func (s EnforcementSpec) IsDryRun() bool {
+	return s.DryRun != nil && *s.DryRun
+}
+
 // ScheduleSpec defines when a policy is active
 type ScheduleSpec struct {
 	// Timezone for schedule interpretation (e.g., "America/Los_Angeles")
 	Timezone string `json:"timezone"`
 
-	// ActiveHours defines when policy is active
-	ActiveHours []ActiveHoursSpec `json:"activeHours"`
+	// ActiveHours defines when policy is active with whole-hour granularity
+	// +optional
+	ActiveHours []ActiveHoursSpec `json:"activeHours,omitempty"`
+
+	// Windows defines when policy is active with minute granularity; a window
+	// whose end is not after its start runs overnight into the next day
+	// +optional
+	Windows []ScheduleWindow `json:"windows,omitempty"`
+
+	// Calendars reference calendar ConfigMaps in the policy namespace that add
+	// active days or blackout days to the schedule
+	// +optional
+	Calendars []CalendarRef `json:"calendars,omitempty"`
+}
+
+// CalendarRef references a calendar ConfigMap with "dates" or "calendar.ics" data
+type CalendarRef struct {
+	// Name of the calendar ConfigMap
+	Name string `json:"name"`
+
+	// Mode is active to enforce during calendar entries (e.g. public holidays)
+	// or blackout to never enforce during them (e.g. release freezes)
+	// +kubebuilder:validation:Enum=active;blackout
+	Mode CalendarMode `json:"mode"`
+}
+
+// CalendarMode defines how calendar entries affect the schedule
+type CalendarMode string
+
+const (
+	CalendarModeActive   CalendarMode = "active"
+	CalendarModeBlackout CalendarMode = "blackout"
+)
+
+// ScheduleWindow defines an active time window in HH:MM format
+type ScheduleWindow struct {
+	// Days of week the window starts on (Mon, Tue, Wed, Thu, Fri, Sat, Sun);
+	// every day when empty
+	// +optional
+	Days []string `json:"days,omitempty"`
+
+	// Start time of the window (HH:MM, inclusive)
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End time of the window (HH:MM, exclusive); 24:00 ends at midnight
+	// +kubebuilder:validation:Pattern=`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`
+	End string `json:"end"`
 }
 
 // ActiveHoursSpec defines active time windows
@@ -151,9 +292,41 @@ type ActiveHoursSpec struct {
 	Hours []int `json:"hours"`
 }
 
+// ReactivationSpec defines when paused workloads are restored automatically
+type ReactivationSpec struct {
+	// Schedule restores every workload paused before one of these times
+	// +optional
+	Schedule []ReactivationTime `json:"schedule,omitempty"`
+
+	// TTL restores each workload once it has been paused for this long
+	// +optional
+	TTL metav1.Duration `json:"ttl,omitempty"`
+
+	// Timezone for reactivation times (defaults to spec.schedule.timezone, then UTC)
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// StaggerInterval is the delay between consecutive restores, so the cluster
+	// autoscaler is not asked for every node at once (defaults to 10s)
+	// +optional
+	StaggerInterval metav1.Duration `json:"staggerInterval,omitempty"`
+}
+
+// ReactivationTime is a weekly wake-up time
+type ReactivationTime struct {
+	// Days of week (Mon, Tue, Wed, Thu, Fri, Sat, Sun); every day when empty
+	// +optional
+	Days []string `json:"days,omitempty"`
+
+	// At is the time of day (HH:MM)
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	At string `json:"at"`
+}
+
 // EnforcementPolicyStatus defines the observed state of EnforcementPolicy
 type EnforcementPolicyStatus struct {
-	// LastEvaluationTime is when policy was last evaluated
+	// LastEvaluationTime is when policy was last evaluated with a status
+	// change; evaluations that change nothing else are not written
 	// +optional
 	LastEvaluationTime *metav1.Time `json:"lastEvaluationTime,omitempty"`
 
@@ -161,25 +334,101 @@ type EnforcementPolicyStatus struct {
 	// +optional
 	MatchedResources int `json:"matchedResources,omitempty"`
 
-	// ActionsPerformed is the count of actions taken by this policy
+	// ActionsPerformed is the lifetime count of actions taken by this policy
 	// +optional
 	ActionsPerformed int `json:"actionsPerformed,omitempty"`
 
-	// EstimatedSavings is the estimated monthly savings in USD
+	// PausedResources is the count of workloads currently paused by this policy
+	// +optional
+	PausedResources int `json:"pausedResources,omitempty"`
+
+	// EstimatedSavings is the estimated monthly savings in USD of the workloads
+	// currently paused by this policy
 	// +optional
 	EstimatedSavings float64 `json:"estimatedSavings,omitempty"`
 
+	// Decisions are the most recent evaluation results, newest first, one per
+	// workload
+	// +optional
+	Decisions []DecisionRecord `json:"decisions,omitempty"`
+
+	// Conflicts lists other policies whose scope overlaps this one; exactly
+	// one policy owns the enforcement decision for each shared workload
+	// +optional
+	Conflicts []PolicyConflict `json:"conflicts,omitempty"`
+
 	// Conditions represent the latest available observations
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// PolicyConflict is another policy matching some of the same workloads
+type PolicyConflict struct {
+	// Policy is the other policy as ClusterEnforcementPolicy/name or
+	// EnforcementPolicy/namespace/name
+	Policy string `json:"policy"`
+
+	// Workloads is the number of workloads both policies match
+	Workloads int `json:"workloads"`
+
+	// Yielded is how many of those workloads the other policy owns
+	Yielded int `json:"yielded"`
+}
+
+// DecisionRecord is the outcome of evaluating one workload
+type DecisionRecord struct {
+	// Resource is the workload as Kind/namespace/name
+	Resource string `json:"resource"`
+
+	// Matched is whether the workload matched the policy
+	Matched bool `json:"matched"`
+
+	// Reason explains the decision
+	Reason string `json:"reason"`
+
+	// Timestamp is when the decision was made
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// EnforcementPolicy condition types
+const (
+	// ConditionReady is true when the policy was reconciled and is enforced
+	ConditionReady = "Ready"
+
+	// ConditionDegraded is true when part of the last reconcile failed, e.g.
+	// some workloads could not be evaluated or paused
+	ConditionDegraded = "Degraded"
+
+	// ConditionCostSourceUnavailable is true when cost data could not be
+	// fetched for some workloads
+	ConditionCostSourceUnavailable = "CostSourceUnavailable"
+
+	// ConditionScheduleInactive is true when the policy schedule is currently
+	// inactive, e.g. outside its windows or during a blackout
+	ConditionScheduleInactive = "ScheduleInactive"
+
+	// ConditionInvalidSpec is true when the spec cannot be enforced, e.g. an
+	// invalid schedule; such policies are not enforced
+	ConditionInvalidSpec = "InvalidSpec"
+
+	// ConditionCircuitBreakerOpen reports whether the cluster-wide circuit
+	// breaker is halting enforcement
+	ConditionCircuitBreakerOpen = "CircuitBreakerOpen"
+
+	// ConditionConflicting is true when other policies match some of the same
+	// workloads; the message lists them
+	ConditionConflicting = "Conflicting"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced
 // +kubebuilder:printcolumn:name="Matched",type=integer,JSONPath=`.status.matchedResources`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="Actions",type=integer,JSONPath=`.status.actionsPerformed`
+// +kubebuilder:printcolumn:name="Paused",type=integer,JSONPath=`.status.pausedResources`
 // +kubebuilder:printcolumn:name="Savings",type=string,JSONPath=`.status.estimatedSavings`
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=`.spec.priority`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // EnforcementPolicy is the Schema for the enforcementpolicies API
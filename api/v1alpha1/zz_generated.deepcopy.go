@@ -0,0 +1,554 @@
+//go:build !ignore_autogenerated
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionsSpec) DeepCopyInto(out *ActionsSpec) {
+	*out = *in
+	if in.Notifiers != nil {
+		in, out := &in.Notifiers, &out.Notifiers
+		*out = make([]NotifyType, len(*in))
+		copy(*out, *in)
+	}
+	out.WarningPeriod = in.WarningPeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionsSpec.
+func (in *ActionsSpec) DeepCopy() *ActionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveHoursSpec) DeepCopyInto(out *ActiveHoursSpec) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hours != nil {
+		in, out := &in.Hours, &out.Hours
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveHoursSpec.
+func (in *ActiveHoursSpec) DeepCopy() *ActiveHoursSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveHoursSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalendarRef) DeepCopyInto(out *CalendarRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalendarRef.
+func (in *CalendarRef) DeepCopy() *CalendarRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CalendarRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterEnforcementPolicy) DeepCopyInto(out *ClusterEnforcementPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterEnforcementPolicy.
+func (in *ClusterEnforcementPolicy) DeepCopy() *ClusterEnforcementPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterEnforcementPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterEnforcementPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterEnforcementPolicyList) DeepCopyInto(out *ClusterEnforcementPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterEnforcementPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterEnforcementPolicyList.
+func (in *ClusterEnforcementPolicyList) DeepCopy() *ClusterEnforcementPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterEnforcementPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterEnforcementPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionsSpec) DeepCopyInto(out *ConditionsSpec) {
+	*out = *in
+	out.IdleWindow = in.IdleWindow
+	if in.TrafficThreshold != nil {
+		in, out := &in.TrafficThreshold, &out.TrafficThreshold
+		*out = new(TrafficThresholdSpec)
+		**out = **in
+	}
+	if in.UtilizationThreshold != nil {
+		in, out := &in.UtilizationThreshold, &out.UtilizationThreshold
+		*out = new(UtilizationThresholdSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionsSpec.
+func (in *ConditionsSpec) DeepCopy() *ConditionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DecisionRecord) DeepCopyInto(out *DecisionRecord) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DecisionRecord.
+func (in *DecisionRecord) DeepCopy() *DecisionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(DecisionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnforcementPolicy) DeepCopyInto(out *EnforcementPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnforcementPolicy.
+func (in *EnforcementPolicy) DeepCopy() *EnforcementPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(EnforcementPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EnforcementPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnforcementPolicyList) DeepCopyInto(out *EnforcementPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EnforcementPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnforcementPolicyList.
+func (in *EnforcementPolicyList) DeepCopy() *EnforcementPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(EnforcementPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EnforcementPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnforcementPolicySpec) DeepCopyInto(out *EnforcementPolicySpec) {
+	*out = *in
+	in.Scope.DeepCopyInto(&out.Scope)
+	in.Conditions.DeepCopyInto(&out.Conditions)
+	in.Actions.DeepCopyInto(&out.Actions)
+	in.Enforcement.DeepCopyInto(&out.Enforcement)
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ScheduleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reactivation != nil {
+		in, out := &in.Reactivation, &out.Reactivation
+		*out = new(ReactivationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnforcementPolicySpec.
+func (in *EnforcementPolicySpec) DeepCopy() *EnforcementPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnforcementPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnforcementPolicyStatus) DeepCopyInto(out *EnforcementPolicyStatus) {
+	*out = *in
+	if in.LastEvaluationTime != nil {
+		in, out := &in.LastEvaluationTime, &out.LastEvaluationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Decisions != nil {
+		in, out := &in.Decisions, &out.Decisions
+		*out = make([]DecisionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]PolicyConflict, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnforcementPolicyStatus.
+func (in *EnforcementPolicyStatus) DeepCopy() *EnforcementPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EnforcementPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnforcementSpec) DeepCopyInto(out *EnforcementSpec) {
+	*out = *in
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	out.CooldownWindow = in.CooldownWindow
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnforcementSpec.
+func (in *EnforcementSpec) DeepCopy() *EnforcementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnforcementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelFilter) DeepCopyInto(out *LabelFilter) {
+	*out = *in
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelFilter.
+func (in *LabelFilter) DeepCopy() *LabelFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceFilter) DeepCopyInto(out *NamespaceFilter) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceFilter.
+func (in *NamespaceFilter) DeepCopy() *NamespaceFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyConflict) DeepCopyInto(out *PolicyConflict) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyConflict.
+func (in *PolicyConflict) DeepCopy() *PolicyConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReactivationSpec) DeepCopyInto(out *ReactivationSpec) {
+	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = make([]ReactivationTime, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.TTL = in.TTL
+	out.StaggerInterval = in.StaggerInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReactivationSpec.
+func (in *ReactivationSpec) DeepCopy() *ReactivationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReactivationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReactivationTime) DeepCopyInto(out *ReactivationTime) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReactivationTime.
+func (in *ReactivationTime) DeepCopy() *ReactivationTime {
+	if in == nil {
+		return nil
+	}
+	out := new(ReactivationTime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleSpec) DeepCopyInto(out *ScheduleSpec) {
+	*out = *in
+	if in.ActiveHours != nil {
+		in, out := &in.ActiveHours, &out.ActiveHours
+		*out = make([]ActiveHoursSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]ScheduleWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Calendars != nil {
+		in, out := &in.Calendars, &out.Calendars
+		*out = make([]CalendarRef, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSpec.
+func (in *ScheduleSpec) DeepCopy() *ScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleWindow) DeepCopyInto(out *ScheduleWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWindow.
+func (in *ScheduleWindow) DeepCopy() *ScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScopeSpec) DeepCopyInto(out *ScopeSpec) {
+	*out = *in
+	in.Namespaces.DeepCopyInto(&out.Namespaces)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(LabelFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]WorkloadKind, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScopeSpec.
+func (in *ScopeSpec) DeepCopy() *ScopeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScopeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficThresholdSpec) DeepCopyInto(out *TrafficThresholdSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficThresholdSpec.
+func (in *TrafficThresholdSpec) DeepCopy() *TrafficThresholdSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficThresholdSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UtilizationThresholdSpec) DeepCopyInto(out *UtilizationThresholdSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UtilizationThresholdSpec.
+func (in *UtilizationThresholdSpec) DeepCopy() *UtilizationThresholdSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UtilizationThresholdSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,147 +1,458 @@
-package controller
 package main
 
 import (
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/api"
 	"github.com/yourusername/finops-enforcer/pkg/controller"
 	"github.com/yourusername/finops-enforcer/pkg/cost"
+	"github.com/yourusername/finops-enforcer/pkg/digest"
 	"github.com/yourusername/finops-enforcer/pkg/enforcement"
+	"github.com/yourusername/finops-enforcer/pkg/guardrail"
 	"github.com/yourusername/finops-enforcer/pkg/notifications"
 	"github.com/yourusername/finops-enforcer/pkg/policy"
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-}	}		os.Exit(1)		setupLog.Error(err, "problem running manager")	if err := mgr.Start(ctx); err != nil {	)		"leader-election", enableLeaderElection,		"max-actions-per-run", maxActionsPerRun,		"opencost-endpoint", opencostEndpoint,		"version", "v0.1.0",	setupLog.Info("starting manager",	}		os.Exit(1)		setupLog.Error(err, "unable to set up ready check")	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {	}		os.Exit(1)		setupLog.Error(err, "unable to set up health check")	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {	// Add health and readiness checks	}		os.Exit(1)		setupLog.Error(err, "unable to create controller", "controller", "EnforcementPolicy")	}).SetupWithManager(mgr); err != nil {		MaxActionsPerRun: maxActionsPerRun,		Notifier:         notifier,		Enforcer:         enforcer,		PolicyEngine:     policyEngine,		CostClient:       costClient,		Scheme:           mgr.GetScheme(),		Client:           mgr.GetClient(),	if err = (&controller.EnforcementPolicyReconciler{	// Set up the reconciler	}		setupLog.Info("slack notifications disabled (no webhook URL provided)")	} else {		setupLog.Info("initialized slack notifier", "channel", slackChannel)		notifier = notifications.NewSlackNotifier(slackWebhookURL, slackChannel)	if slackWebhookURL != "" {	var notifier *notifications.SlackNotifier	// Initialize Slack notifier (if configured)	setupLog.Info("initialized enforcement executor")	enforcer := enforcement.NewExecutor(mgr.GetClient())	// Initialize enforcement executor	setupLog.Info("initialized policy engine")	policyEngine := policy.NewEngine()	// Initialize policy engine	}		setupLog.Info("opencost health check passed")	} else {		setupLog.Error(err, "opencost health check failed - continuing anyway")	if err := costClient.HealthCheck(ctx); err != nil {	ctx := ctrl.SetupSignalHandler()	// Health check OpenCost	setupLog.Info("initialized opencost client", "endpoint", opencostEndpoint)	costClient := cost.NewClient(opencostEndpoint, opencostTimeout)	// Initialize OpenCost client	}		os.Exit(1)		setupLog.Error(err, "unable to start manager")	if err != nil {	})		LeaderElectionID:       "finops-enforcer.finops.io",		LeaderElection:         enableLeaderElection,		HealthProbeBindAddress: probeAddr,		},			BindAddress: metricsAddr,		Metrics: metricsserver.Options{		Scheme: scheme,	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))	flag.Parse()	opts.BindFlags(flag.CommandLine)	}		Development: true,	opts := zap.Options{		"Maximum enforcement actions per reconciliation run")	flag.IntVar(&maxActionsPerRun, "max-actions-per-run", 10,		"Slack channel for notifications")	flag.StringVar(&slackChannel, "slack-channel", "#finops-alerts",		"Slack webhook URL for notifications")	flag.StringVar(&slackWebhookURL, "slack-webhook-url", os.Getenv("SLACK_WEBHOOK_URL"),		"Timeout for OpenCost API requests")	flag.DurationVar(&opencostTimeout, "opencost-timeout", 30*time.Second,		"OpenCost API endpoint")	flag.StringVar(&opencostEndpoint, "opencost-endpoint", "http://opencost.opencost:9003",			"Enabling this will ensure there is only one active controller manager.")		"Enable leader election for controller manager. "+	flag.BoolVar(&enableLeaderElection, "leader-elect", false,	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")	var maxActionsPerRun int	var slackChannel string	var slackWebhookURL string	var opencostTimeout time.Duration	var opencostEndpoint string	var probeAddr string	var enableLeaderElection bool	var metricsAddr stringfunc main() {}	utilruntime.Must(finopsv1alpha1.AddToScheme(scheme))	utilruntime.Must(clientgoscheme.AddToScheme(scheme))func init() {)	setupLog = ctrl.Log.WithName("setup")	scheme   = runtime.NewScheme()var ()	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"	"sigs.k8s.io/controller-runtime/pkg/log/zap"	"sigs.k8s.io/controller-runtime/pkg/healthz"	ctrl "sigs.k8s.io/controller-runtime"	clientgoscheme "k8s.io/client-go/kubernetes/scheme"	utilruntime "k8s.io/apimachinery/pkg/util/runtime"	"k8s.io/apimachinery/pkg/runtime"
\ No newline at end of file
+	"github.com/yourusername/finops-enforcer/pkg/prometheus"
+	"github.com/yourusername/finops-enforcer/pkg/server"
+	"github.com/yourusername/finops-enforcer/pkg/webhook"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(finopsv1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	var metricsAddr string
+	var enableLeaderElection bool
+	var probeAddr string
+	var opencostEndpoint string
+	var opencostTimeout time.Duration
+	var opencostCacheTTL time.Duration
+	var costProvider string
+	var kubecostEndpoint string
+	var priceSheetConfigMap string
+	var slackWebhookURL string
+	var slackChannel string
+	var slackSigningSecret string
+	var teamsWebhookURL string
+	var notificationWebhookURL string
+	var smtpConfig notifications.SMTPConfig
+	var smtpTo string
+	var ownerRoutingConfigMap string
+	var digestConfigMap string
+	var digestInterval time.Duration
+	var apiAddr string
+	var enableReactivationAPI bool
+	var maxActionsPerRun int
+	var prometheusEndpoint string
+	var prometheusTimeout time.Duration
+	var activityInterval time.Duration
+	var activityCPUThreshold float64
+	var falsePositiveWindow time.Duration
+	var guardConfig guardrail.Config
+	var enableWebhooks bool
+	var webhookPort int
+	var webhookCertDir string
+	var protectedNamespaces string
+	var clusterPolicyNamespace string
+
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
+		"Enable leader election for controller manager. "+
+			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&opencostEndpoint, "opencost-endpoint", "http://opencost.opencost:9003",
+		"OpenCost API endpoint")
+	flag.DurationVar(&opencostTimeout, "opencost-timeout", 30*time.Second,
+		"Timeout for OpenCost API requests")
+	flag.DurationVar(&opencostCacheTTL, "opencost-cache-ttl", 5*time.Minute,
+		"How long cluster-wide OpenCost/Kubecost allocation results are cached")
+	flag.StringVar(&costProvider, "cost-provider", cost.ProviderOpenCost,
+		"Cost data provider: opencost, kubecost or pricesheet")
+	flag.StringVar(&kubecostEndpoint, "kubecost-endpoint", "http://kubecost-cost-analyzer.kubecost:9090",
+		"Kubecost API endpoint (used with --cost-provider=kubecost)")
+	flag.StringVar(&priceSheetConfigMap, "price-sheet-configmap", "finops-system/finops-price-sheet",
+		"Namespace/name of the price sheet ConfigMap (used with --cost-provider=pricesheet)")
+	flag.StringVar(&slackWebhookURL, "slack-webhook-url", os.Getenv("SLACK_WEBHOOK_URL"),
+		"Slack webhook URL for notifications")
+	flag.StringVar(&slackChannel, "slack-channel", "#finops-alerts",
+		"Slack channel for notifications")
+	flag.StringVar(&slackSigningSecret, "slack-signing-secret", os.Getenv("SLACK_SIGNING_SECRET"),
+		"Slack app signing secret for verifying interactive button callbacks (disabled if empty)")
+	flag.StringVar(&teamsWebhookURL, "teams-webhook-url", os.Getenv("TEAMS_WEBHOOK_URL"),
+		"Microsoft Teams incoming webhook URL for notifications")
+	flag.StringVar(&notificationWebhookURL, "notification-webhook-url", os.Getenv("NOTIFICATION_WEBHOOK_URL"),
+		"Generic JSON webhook URL for notifications")
+	flag.StringVar(&smtpConfig.Host, "smtp-host", "",
+		"SMTP server for email notifications (disabled if empty)")
+	flag.IntVar(&smtpConfig.Port, "smtp-port", 587,
+		"SMTP server port")
+	flag.StringVar(&smtpConfig.Username, "smtp-username", os.Getenv("SMTP_USERNAME"),
+		"SMTP username (no authentication if empty)")
+	flag.StringVar(&smtpConfig.Password, "smtp-password", os.Getenv("SMTP_PASSWORD"),
+		"SMTP password")
+	flag.StringVar(&smtpConfig.From, "smtp-from", "finops-enforcer@localhost",
+		"Sender address of email notifications")
+	flag.StringVar(&smtpTo, "smtp-to", "",
+		"Comma-separated recipients of email notifications")
+	flag.StringVar(&ownerRoutingConfigMap, "owner-routing-configmap", "finops-system/finops-owner-routing",
+		"Namespace/name of the ConfigMap mapping teams to Slack channels and email addresses")
+	flag.StringVar(&digestConfigMap, "digest-configmap", "finops-system/finops-digest",
+		"Namespace/name of the ConfigMap persisting notification digest state")
+	flag.DurationVar(&digestInterval, "digest-interval", 10*time.Minute,
+		"How often policies are checked for a finished digest period")
+	flag.StringVar(&apiAddr, "api-bind-address", ":8082",
+		"The address the interaction endpoints bind to.")
+	flag.BoolVar(&enableReactivationAPI, "enable-reactivation-api", false,
+		"Serve the self-service reactivation API on the api bind address. "+
+			"Callers send bearer tokens, so only enable it behind TLS termination.")
+	flag.IntVar(&maxActionsPerRun, "max-actions-per-run", 10,
+		"Maximum enforcement actions per reconciliation run")
+	flag.StringVar(&prometheusEndpoint, "prometheus-endpoint", "",
+		"Prometheus-compatible API endpoint for traffic and utilization idle detection (disabled if empty)")
+	flag.DurationVar(&prometheusTimeout, "prometheus-timeout", 30*time.Second,
+		"Timeout for Prometheus API requests")
+	flag.DurationVar(&activityInterval, "activity-interval", 10*time.Minute,
+		"How often workload activity is sampled to update finops.io/last-activity")
+	flag.Float64Var(&activityCPUThreshold, "activity-cpu-threshold", 10,
+		"CPU utilization (percent of requests) above which a workload counts as active")
+	flag.DurationVar(&falsePositiveWindow, "false-positive-window", time.Hour,
+		"Manual reactivations within this long of a pause are counted as false positives")
+	flag.IntVar(&guardConfig.MaxActionsPerHour, "max-actions-per-hour", 50,
+		"Maximum pauses across the cluster in any hour, shared by all policies (0 disables)")
+	flag.IntVar(&guardConfig.MaxActionsPerNamespacePerHour, "max-actions-per-namespace-per-hour", 10,
+		"Maximum pauses in one namespace in any hour, shared by all policies (0 disables)")
+	flag.DurationVar(&guardConfig.BreakerWindow, "circuit-breaker-window", time.Hour,
+		"Window over which the circuit breaker measures false positive and reactivation rates")
+	flag.Float64Var(&guardConfig.MaxFalsePositiveRate, "circuit-breaker-max-false-positive-rate", 0.2,
+		"False positives per pause in the window above which enforcement halts (0 disables)")
+	flag.Float64Var(&guardConfig.MaxReactivationRate, "circuit-breaker-max-reactivation-rate", 0.5,
+		"Reactivations per pause in the window above which enforcement halts (0 disables)")
+	flag.IntVar(&guardConfig.MinPauses, "circuit-breaker-min-pauses", 10,
+		"Pauses required in the window before the circuit breaker evaluates rates")
+	flag.StringVar(&clusterPolicyNamespace, "cluster-policy-namespace", "finops-system",
+		"Namespace ClusterEnforcementPolicy schedule calendars are read from")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the EnforcementPolicy defaulting and validating admission webhooks")
+	flag.IntVar(&webhookPort, "webhook-port", 9443,
+		"The port the admission webhook server binds to.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs",
+		"Directory containing tls.crt and tls.key for the admission webhook server")
+	flag.StringVar(&protectedNamespaces, "protected-namespaces", strings.Join(policy.DefaultProtectedNamespaces, ","),
+		"Comma-separated namespaces the admission webhook warns about when a policy scope matches them")
+
+	opts := zap.Options{
+		Development: true,
+	}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme: scheme,
+		Metrics: metricsserver.Options{
+			BindAddress: metricsAddr,
+		},
+		HealthProbeBindAddress: probeAddr,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       "finops-enforcer.finops.io",
+		WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+		}),
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
+	// ConfigMaps (price sheet, calendars, digest state, team mapping) and
+	// Namespaces looked up for routing are read uncached, so the controller
+	// does not watch every ConfigMap in the cluster
+	apiReader := mgr.GetAPIReader()
+
+	// Initialize cost provider
+	var costClient cost.CostProvider
+	switch costProvider {
+	case cost.ProviderOpenCost:
+		costClient = cost.NewCache(cost.NewClient(opencostEndpoint, opencostTimeout), opencostCacheTTL)
+		setupLog.Info("initialized opencost client", "endpoint", opencostEndpoint, "cache-ttl", opencostCacheTTL)
+	case cost.ProviderKubecost:
+		costClient = cost.NewCache(cost.NewKubecostClient(kubecostEndpoint, opencostTimeout), opencostCacheTTL)
+		setupLog.Info("initialized kubecost client", "endpoint", kubecostEndpoint, "cache-ttl", opencostCacheTTL)
+	case cost.ProviderPriceSheet:
+		key, err := parseObjectKey(priceSheetConfigMap)
+		if err != nil {
+			setupLog.Error(err, "invalid --price-sheet-configmap")
+			os.Exit(1)
+		}
+		costClient = cost.NewPriceSheetProvider(mgr.GetClient(), apiReader, key, time.Minute)
+		setupLog.Info("initialized price sheet cost provider", "configmap", key)
+	default:
+		setupLog.Error(fmt.Errorf("unknown cost provider %q", costProvider), "invalid --cost-provider")
+		os.Exit(1)
+	}
+
+	// Health check cost provider
+	ctx := ctrl.SetupSignalHandler()
+	if err := costClient.HealthCheck(ctx); err != nil {
+		setupLog.Error(err, "cost provider health check failed - continuing anyway", "provider", costProvider)
+	} else {
+		setupLog.Info("cost provider health check passed", "provider", costProvider)
+	}
+
+	// Initialize Prometheus client (if configured)
+	engineOpts := []policy.Option{}
+	var promClient *prometheus.Client
+	if prometheusEndpoint != "" {
+		promClient = prometheus.NewClient(prometheusEndpoint, prometheusTimeout)
+		engineOpts = append(engineOpts,
+			policy.WithTrafficSource(promClient),
+			policy.WithUtilizationSource(promClient),
+		)
+		setupLog.Info("initialized prometheus client", "endpoint", prometheusEndpoint)
+	} else {
+		setupLog.Info("traffic and utilization idle detection disabled (no prometheus endpoint provided)")
+	}
+
+	// Schedule calendars are ConfigMaps in the policy namespace
+	engineOpts = append(engineOpts,
+		policy.WithCalendarSource(policy.NewConfigMapCalendars(apiReader, time.Minute)),
+		policy.WithNamespaceReader(mgr.GetClient()),
+	)
+
+	// Initialize policy engine
+	policyEngine := policy.NewEngine(engineOpts...)
+	setupLog.Info("initialized policy engine")
+
+	// Initialize digest state
+	digestKey, err := parseObjectKey(digestConfigMap)
+	if err != nil {
+		setupLog.Error(err, "invalid --digest-configmap")
+		os.Exit(1)
+	}
+	digestStore := digest.NewStore(mgr.GetClient(), apiReader, digestKey)
+
+	// Initialize the cluster-wide action budget and circuit breaker
+	guard := guardrail.NewGuard(guardConfig)
+	setupLog.Info("initialized action budget and circuit breaker",
+		"max-actions-per-hour", guardConfig.MaxActionsPerHour,
+		"max-actions-per-namespace-per-hour", guardConfig.MaxActionsPerNamespacePerHour,
+	)
+
+	enforcer := enforcement.NewExecutor(mgr.GetClient(),
+		enforcement.WithDigest(digestStore),
+		enforcement.WithGuard(guard),
+	)
+	setupLog.Info("initialized enforcement executor")
+
+	// Initialize notifiers (if configured)
+	notifiers := map[finopsv1alpha1.NotifyType]notifications.Notifier{}
+	if slackWebhookURL != "" {
+		notifiers[finopsv1alpha1.NotifyTypeSlack] = notifications.NewSlackNotifier(slackWebhookURL, slackChannel)
+		setupLog.Info("initialized slack notifier", "channel", slackChannel)
+	} else {
+		setupLog.Info("slack notifications disabled (no webhook URL provided)")
+	}
+	if teamsWebhookURL != "" {
+		notifiers[finopsv1alpha1.NotifyTypeTeams] = notifications.NewTeamsNotifier(teamsWebhookURL)
+		setupLog.Info("initialized teams notifier")
+	}
+	if notificationWebhookURL != "" {
+		notifiers[finopsv1alpha1.NotifyTypeWebhook] = notifications.NewWebhookNotifier(notificationWebhookURL)
+		setupLog.Info("initialized webhook notifier")
+	}
+	if smtpConfig.Host != "" {
+		smtpConfig.To = splitList(smtpTo)
+		notifiers[finopsv1alpha1.NotifyTypeEmail] = notifications.NewEmailNotifier(smtpConfig)
+		setupLog.Info("initialized email notifier", "smtp-host", smtpConfig.Host, "recipients", len(smtpConfig.To))
+	}
+
+	// Initialize owner routing
+	routingKey, err := parseObjectKey(ownerRoutingConfigMap)
+	if err != nil {
+		setupLog.Error(err, "invalid --owner-routing-configmap")
+		os.Exit(1)
+	}
+	router := notifications.NewRouter(apiReader, routingKey, time.Minute)
+	setupLog.Info("initialized notification routing", "configmap", routingKey)
+
+	// Set up the reconciler
+	if err = (&controller.EnforcementPolicyReconciler{
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		CostClient:       costClient,
+		PolicyEngine:     policyEngine,
+		Enforcer:         enforcer,
+		Notifiers:        notifiers,
+		Router:           router,
+		Digest:           digestStore,
+		Guard:            guard,
+		MaxActionsPerRun: maxActionsPerRun,
+
+		ClusterPolicyNamespace: clusterPolicyNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EnforcementPolicy")
+		os.Exit(1)
+	}
+
+	// Set up admission webhooks (require a serving certificate)
+	if enableWebhooks {
+		if err = (&webhook.PolicyWebhook{
+			ProtectedNamespaces: splitList(protectedNamespaces),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "EnforcementPolicy")
+			os.Exit(1)
+		}
+		setupLog.Info("admission webhooks enabled", "port", webhookPort)
+	}
+
+	// Set up manual reactivation detection
+	for _, kind := range workload.SupportedKinds {
+		if err = (&controller.ManualReactivationReconciler{
+			Client:              mgr.GetClient(),
+			Kind:                kind,
+			Enforcer:            enforcer,
+			Digest:              digestStore,
+			Guard:               guard,
+			FalsePositiveWindow: falsePositiveWindow,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ManualReactivation", "kind", kind)
+			os.Exit(1)
+		}
+	}
+
+	// Set up interaction endpoints
+	mux := http.NewServeMux()
+	if slackSigningSecret != "" {
+		mux.Handle("/slack/interactions", notifications.NewSlackInteractionHandler(slackSigningSecret, enforcer))
+		setupLog.Info("slack interactions enabled", "path", "/slack/interactions")
+	} else {
+		setupLog.Info("slack interactions disabled (no signing secret provided)")
+	}
+	if enableReactivationAPI {
+		mux.Handle(api.PathPrefix, api.NewHandler(
+			api.NewKubernetesAuthorizer(mgr.GetClient()),
+			enforcer,
+			mgr.GetEventRecorderFor("finops-enforcer"),
+		))
+		setupLog.Info("reactivation api enabled", "path", api.PathPrefix)
+	}
+	// Reactivations go through the leader's action budget and circuit breaker,
+	// so the api server runs on the leader only and the leader labels its pod
+	// for the api Service to select
+	if err := mgr.Add(&server.Server{Name: "api", Addr: apiAddr, Handler: mux, LeaderOnly: true}); err != nil {
+		setupLog.Error(err, "unable to set up api server")
+		os.Exit(1)
+	}
+	podName, podNamespace := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE")
+	if podName != "" && podNamespace != "" {
+		if err := server.ClearLeaderLabel(ctx, mgr.GetClient(), podNamespace, podName); err != nil {
+			setupLog.Error(err, "unable to clear stale leader label")
+			os.Exit(1)
+		}
+		if err := mgr.Add(&server.LeaderLabeler{
+			Client:    mgr.GetClient(),
+			Namespace: podNamespace,
+			Name:      podName,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up leader labeler")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("leader labeling disabled (POD_NAME or POD_NAMESPACE not set)")
+	}
+
+	// Set up the activity tracker (requires Prometheus)
+	if promClient != nil {
+		if err := mgr.Add(&controller.ActivityTracker{
+			Client:       mgr.GetClient(),
+			Traffic:      promClient,
+			Utilization:  promClient,
+			Interval:     activityInterval,
+			CPUThreshold: activityCPUThreshold,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up activity tracker")
+			os.Exit(1)
+		}
+		setupLog.Info("initialized activity tracker", "interval", activityInterval)
+	}
+
+	// Set up the digest sender
+	if err := mgr.Add(&controller.DigestSender{
+		Client:    mgr.GetClient(),
+		Store:     digestStore,
+		Notifiers: notifiers,
+		Router:    router,
+		Interval:  digestInterval,
+	}); err != nil {
+		setupLog.Error(err, "unable to set up digest sender")
+		os.Exit(1)
+	}
+
+	// Add health and readiness checks
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+
+	setupLog.Info("starting manager",
+		"version", "v0.1.0",
+		"cost-provider", costProvider,
+		"max-actions-per-run", maxActionsPerRun,
+		"leader-election", enableLeaderElection,
+	)
+	if err := mgr.Start(ctx); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// parseObjectKey parses a "namespace/name" reference
+func parseObjectKey(s string) (types.NamespacedName, error) {
+	namespace, name, ok := strings.Cut(s, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("expected namespace/name, got %q", s)
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
@@ -1,4 +1,3 @@
-package controller
 package main
 
 import "testing"
@@ -7,3 +6,38 @@ func TestMain(t *testing.T) {
 	// Placeholder test to satisfy go test
 	t.Log("Main controller test placeholder")
 }
+
+func TestParseObjectKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "valid", input: "finops-system/finops-price-sheet", want: "finops-system/finops-price-sheet"},
+		{name: "missing namespace", input: "finops-price-sheet", wantErr: true},
+		{name: "empty name", input: "finops-system/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseObjectKey(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseObjectKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("parseObjectKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	got := splitList(" team-a@example.com, ,team-b@example.com,")
+	if len(got) != 2 || got[0] != "team-a@example.com" || got[1] != "team-b@example.com" {
+		t.Errorf("splitList() = %v", got)
+	}
+	if got := splitList(""); got != nil {
+		t.Errorf("splitList(\"\") = %v, want nil", got)
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Authorizer authenticates API callers and checks their access
+type Authorizer interface {
+	// Authenticate resolves a bearer token to a user
+	Authenticate(ctx context.Context, token string) (*authenticationv1.UserInfo, error)
+
+	// Authorize checks whether a user may perform an action on a resource
+	Authorize(ctx context.Context, user *authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error)
+}
+
+// KubernetesAuthorizer delegates to the API server via TokenReview and SubjectAccessReview
+type KubernetesAuthorizer struct {
+	client client.Client
+}
+
+// NewKubernetesAuthorizer creates a new Kubernetes-backed authorizer
+func NewKubernetesAuthorizer(c client.Client) *KubernetesAuthorizer {
+	return &KubernetesAuthorizer{client: c}
+}
+
+// Authenticate validates a bearer token with a TokenReview
+func (a *KubernetesAuthorizer) Authenticate(ctx context.Context, token string) (*authenticationv1.UserInfo, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	if err := a.client.Create(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to create token review: %w", err)
+	}
+
+	if !review.Status.Authenticated {
+		return nil, fmt.Errorf("token not authenticated: %s", review.Status.Error)
+	}
+
+	return &review.Status.User, nil
+}
+
+// Authorize checks access with a SubjectAccessReview
+func (a *KubernetesAuthorizer) Authorize(
+	ctx context.Context,
+	user *authenticationv1.UserInfo,
+	attributes authorizationv1.ResourceAttributes,
+) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}
+	if err := a.client.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("failed to create subject access review: %w", err)
+	}
+
+	return review.Status.Allowed, nil
+}
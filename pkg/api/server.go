@@ -0,0 +1,365 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/finops-enforcer/pkg/enforcement"
+	"github.com/yourusername/finops-enforcer/pkg/metrics"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// PathPrefix is where the reactivation API is mounted
+const PathPrefix = "/api/v1/"
+
+// Authorization checked for reactivations: the "reactivate" verb on the
+// virtual finops.io/workloads resource, granted with RBAC like any other
+const (
+	ReactivateGroup    = "finops.io"
+	ReactivateResource = "workloads"
+	ReactivateVerb     = "reactivate"
+)
+
+// Workloads lists and reactivates paused workloads
+type Workloads interface {
+	GetPausedWorkloads(ctx context.Context, namespace string) ([]*workload.Workload, error)
+	Reactivate(ctx context.Context, key workload.Key, source string) (*workload.Workload, error)
+}
+
+// Handler serves the self-service reactivation API:
+//
+//	GET  /api/v1/namespaces/{namespace}/paused
+//	POST /api/v1/namespaces/{namespace}/reactivate
+//	POST /api/v1/namespaces/{namespace}/{resource}/{name}/reactivate
+//
+// Callers authenticate with a Kubernetes bearer token. Listing needs "list"
+// access to each kind; reactivating needs the "reactivate" verb on
+// finops.io/workloads in the namespace, so developers can wake workloads
+// without being able to scale or patch them.
+type Handler struct {
+	authorizer Authorizer
+	workloads  Workloads
+	recorder   record.EventRecorder
+}
+
+// NewHandler creates a new reactivation API handler
+func NewHandler(authorizer Authorizer, workloads Workloads, recorder record.EventRecorder) *Handler {
+	return &Handler{
+		authorizer: authorizer,
+		workloads:  workloads,
+		recorder:   recorder,
+	}
+}
+
+// PausedWorkload describes a paused workload in API responses
+type PausedWorkload struct {
+	Kind                    workload.Kind `json:"kind"`
+	Namespace               string        `json:"namespace"`
+	Name                    string        `json:"name"`
+	Policy                  string        `json:"policy,omitempty"`
+	PausedAt                string        `json:"pausedAt,omitempty"`
+	Reason                  string        `json:"reason,omitempty"`
+	EstimatedMonthlySavings float64       `json:"estimatedMonthlySavings"`
+}
+
+// ReactivationResult reports the outcome of reactivating one workload
+type ReactivationResult struct {
+	Kind      workload.Kind `json:"kind"`
+	Namespace string        `json:"namespace"`
+	Name      string        `json:"name"`
+	Replicas  int32         `json:"replicas,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// errorResponse is the body of failed requests
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP routes API requests
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, PathPrefix), "/"), "/")
+	if len(parts) < 3 || parts[0] != "namespaces" || parts[1] == "" {
+		writeJSON(rw, http.StatusNotFound, errorResponse{Error: "not found"})
+		return
+	}
+	namespace := parts[1]
+
+	// Unauthenticated requests are only logged, so anonymous callers cannot
+	// flood namespaces with Events
+	user, err := h.authenticate(r)
+	if err != nil {
+		log.FromContext(r.Context()).WithName("api").Info("rejected unauthenticated request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"error", err.Error(),
+		)
+		writeJSON(rw, http.StatusUnauthorized, errorResponse{Error: "unauthorized"})
+		return
+	}
+
+	switch {
+	case len(parts) == 3 && parts[2] == "paused" && r.Method == http.MethodGet:
+		h.listPaused(rw, r, user, namespace)
+	case len(parts) == 3 && parts[2] == "reactivate" && r.Method == http.MethodPost:
+		h.reactivateNamespace(rw, r, user, namespace)
+	case len(parts) == 5 && parts[4] == "reactivate" && r.Method == http.MethodPost:
+		kind, ok := kindForResource(parts[2])
+		if !ok {
+			writeJSON(rw, http.StatusNotFound, errorResponse{Error: fmt.Sprintf("unsupported resource %q", parts[2])})
+			return
+		}
+		h.reactivateOne(rw, r, user, workload.Key{Kind: kind, Namespace: namespace, Name: parts[3]})
+	default:
+		writeJSON(rw, http.StatusNotFound, errorResponse{Error: "not found"})
+	}
+}
+
+// listPaused returns the paused workloads of every kind the user may list
+func (h *Handler) listPaused(rw http.ResponseWriter, r *http.Request, user *authenticationv1.UserInfo, namespace string) {
+	ctx := r.Context()
+
+	allowedKinds := map[workload.Kind]bool{}
+	for _, kind := range workload.SupportedKinds {
+		allowed, err := h.authorizer.Authorize(ctx, user, resourceAttributes(kind, namespace, "", "list"))
+		if err != nil {
+			writeJSON(rw, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+		allowedKinds[kind] = allowed
+	}
+
+	if !anyAllowed(allowedKinds) {
+		h.namespaceEvent(namespace, corev1.EventTypeWarning, "APIAccessDenied",
+			fmt.Sprintf("User %s is not allowed to list paused workloads", user.Username))
+		writeJSON(rw, http.StatusForbidden, errorResponse{Error: "forbidden"})
+		return
+	}
+
+	paused, err := h.workloads.GetPausedWorkloads(ctx, namespace)
+	if err != nil {
+		writeJSON(rw, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+
+	items := []PausedWorkload{}
+	for _, w := range paused {
+		if allowedKinds[w.Kind] {
+			items = append(items, toPausedWorkload(w))
+		}
+	}
+
+	h.namespaceEvent(namespace, corev1.EventTypeNormal, "PausedWorkloadsListed",
+		fmt.Sprintf("User %s listed %d paused workloads via API", user.Username, len(items)))
+	writeJSON(rw, http.StatusOK, items)
+}
+
+// reactivateOne reactivates a single workload
+func (h *Handler) reactivateOne(rw http.ResponseWriter, r *http.Request, user *authenticationv1.UserInfo, key workload.Key) {
+	ctx := r.Context()
+
+	allowed, err := h.authorizer.Authorize(ctx, user, reactivationAttributes(key))
+	if err != nil {
+		writeJSON(rw, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+	if !allowed {
+		h.namespaceEvent(key.Namespace, corev1.EventTypeWarning, "APIAccessDenied",
+			fmt.Sprintf("User %s is not allowed to reactivate %s", user.Username, key))
+		writeJSON(rw, http.StatusForbidden, errorResponse{Error: "forbidden"})
+		return
+	}
+
+	result, err := h.reactivate(ctx, user, key)
+	writeJSON(rw, reactivationStatus(err), result)
+}
+
+// reactivateNamespace reactivates every paused workload in a namespace the user can access
+func (h *Handler) reactivateNamespace(rw http.ResponseWriter, r *http.Request, user *authenticationv1.UserInfo, namespace string) {
+	ctx := r.Context()
+
+	paused, err := h.workloads.GetPausedWorkloads(ctx, namespace)
+	if err != nil {
+		writeJSON(rw, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+
+	results := []ReactivationResult{}
+	for _, w := range paused {
+		key := w.Key()
+		allowed, err := h.authorizer.Authorize(ctx, user, reactivationAttributes(key))
+		if err != nil {
+			writeJSON(rw, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+		if !allowed {
+			continue
+		}
+
+		result, _ := h.reactivate(ctx, user, key)
+		results = append(results, result)
+	}
+
+	h.namespaceEvent(namespace, corev1.EventTypeNormal, "NamespaceReactivationRequested",
+		fmt.Sprintf("User %s requested reactivation of %d paused workloads via API", user.Username, len(results)))
+	writeJSON(rw, http.StatusOK, results)
+}
+
+// reactivate restores a workload and records an Event on it
+func (h *Handler) reactivate(ctx context.Context, user *authenticationv1.UserInfo, key workload.Key) (ReactivationResult, error) {
+	logger := log.FromContext(ctx).WithName("api")
+	result := ReactivationResult{Kind: key.Kind, Namespace: key.Namespace, Name: key.Name}
+
+	w, err := h.workloads.Reactivate(ctx, key, metrics.ReactivationSourceAPI)
+	if err != nil {
+		logger.Error(err, "api reactivation failed",
+			"kind", key.Kind,
+			"workload", key.Name,
+			"namespace", key.Namespace,
+			"user", user.Username,
+		)
+		result.Error = err.Error()
+		if target, getErr := workload.New(key.Kind); getErr == nil {
+			target.SetNamespace(key.Namespace)
+			target.SetName(key.Name)
+			h.recorder.Eventf(target.Object, corev1.EventTypeWarning, "ReactivationFailed",
+				"Reactivation requested by %s via API failed: %v", user.Username, err)
+		}
+		return result, err
+	}
+
+	logger.Info("workload reactivated via api",
+		"kind", key.Kind,
+		"workload", key.Name,
+		"namespace", key.Namespace,
+		"user", user.Username,
+	)
+	result.Replicas = w.Replicas()
+	h.recorder.Eventf(w.Object, corev1.EventTypeNormal, "Reactivated",
+		"Reactivated by %s via API", user.Username)
+	return result, nil
+}
+
+// authenticate extracts and validates the bearer token of a request
+func (h *Handler) authenticate(r *http.Request) (*authenticationv1.UserInfo, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return nil, fmt.Errorf("missing bearer token")
+	}
+
+	return h.authorizer.Authenticate(r.Context(), token)
+}
+
+// namespaceEvent records an Event on a namespace
+func (h *Handler) namespaceEvent(namespace, eventType, reason, message string) {
+	h.recorder.Event(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}, eventType, reason, message)
+}
+
+// reactivationStatus maps a reactivation error to an HTTP status code
+func reactivationStatus(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, enforcement.ErrReactivationNotAllowed):
+		return http.StatusForbidden
+	case apierrors.IsNotFound(err):
+		return http.StatusNotFound
+	default:
+		return http.StatusConflict
+	}
+}
+
+// resourceAttributes builds the SubjectAccessReview attributes for a workload kind
+func resourceAttributes(kind workload.Kind, namespace, name, verb string) authorizationv1.ResourceAttributes {
+	group, resource := kindGroupResource(kind)
+	return authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      verb,
+		Group:     group,
+		Resource:  resource,
+		Name:      name,
+	}
+}
+
+// reactivationAttributes builds the SubjectAccessReview attributes for
+// reactivating a workload of any kind. The workload name is the resource
+// name, so RBAC resourceNames can restrict which workloads a user may wake.
+func reactivationAttributes(key workload.Key) authorizationv1.ResourceAttributes {
+	return authorizationv1.ResourceAttributes{
+		Namespace: key.Namespace,
+		Verb:      ReactivateVerb,
+		Group:     ReactivateGroup,
+		Resource:  ReactivateResource,
+		Name:      key.Name,
+	}
+}
+
+// kindGroupResource returns the API group and resource name of a workload kind
+func kindGroupResource(kind workload.Kind) (string, string) {
+	switch kind {
+	case workload.KindCronJob:
+		return "batch", "cronjobs"
+	case workload.KindStatefulSet:
+		return "apps", "statefulsets"
+	default:
+		return "apps", "deployments"
+	}
+}
+
+// kindForResource maps a resource name from the URL to a workload kind
+func kindForResource(resource string) (workload.Kind, bool) {
+	for _, kind := range workload.SupportedKinds {
+		if _, r := kindGroupResource(kind); r == resource {
+			return kind, true
+		}
+	}
+
+	return "", false
+}
+
+// anyAllowed reports whether at least one kind is allowed
+func anyAllowed(allowed map[workload.Kind]bool) bool {
+	for _, ok := range allowed {
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toPausedWorkload converts a workload to its API representation
+func toPausedWorkload(w *workload.Workload) PausedWorkload {
+	annotations := w.GetAnnotations()
+	savings, _ := strconv.ParseFloat(annotations["finops.io/estimated-monthly-savings"], 64)
+	return PausedWorkload{
+		Kind:                    w.Kind,
+		Namespace:               w.GetNamespace(),
+		Name:                    w.GetName(),
+		Policy:                  annotations["finops.io/policy"],
+		PausedAt:                annotations["finops.io/paused-at"],
+		Reason:                  annotations["finops.io/reason"],
+		EstimatedMonthlySavings: savings,
+	}
+}
+
+// writeJSON writes a JSON response
+func writeJSON(rw http.ResponseWriter, status int, body interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_ = json.NewEncoder(rw).Encode(body)
+}
+
+var _ Workloads = &enforcement.Executor{}
@@ -0,0 +1,290 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/finops-enforcer/pkg/enforcement"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// fakeAuthorizer accepts the token "valid" and allows access to the listed
+// namespaces, read-only access to readOnly namespaces and read and
+// reactivate access to reactivators namespaces
+type fakeAuthorizer struct {
+	namespaces   map[string]bool
+	readOnly     map[string]bool
+	reactivators map[string]bool
+}
+
+func (f fakeAuthorizer) Authenticate(ctx context.Context, token string) (*authenticationv1.UserInfo, error) {
+	if token != "valid" {
+		return nil, fmt.Errorf("invalid token")
+	}
+	return &authenticationv1.UserInfo{Username: "dev@example.com"}, nil
+}
+
+func (f fakeAuthorizer) Authorize(ctx context.Context, user *authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	read := attributes.Verb == "get" || attributes.Verb == "list"
+	if f.readOnly[attributes.Namespace] {
+		return read, nil
+	}
+	if f.reactivators[attributes.Namespace] {
+		return read || (attributes.Verb == ReactivateVerb &&
+			attributes.Group == ReactivateGroup && attributes.Resource == ReactivateResource), nil
+	}
+	return f.namespaces[attributes.Namespace], nil
+}
+
+// fakeWorkloads serves paused deployments from memory
+type fakeWorkloads struct {
+	paused      []*workload.Workload
+	locked      map[string]bool
+	reactivated []string
+	source      string
+}
+
+func (f *fakeWorkloads) GetPausedWorkloads(ctx context.Context, namespace string) ([]*workload.Workload, error) {
+	result := []*workload.Workload{}
+	for _, w := range f.paused {
+		if w.GetNamespace() == namespace {
+			result = append(result, w)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeWorkloads) Reactivate(ctx context.Context, key workload.Key, source string) (*workload.Workload, error) {
+	if f.locked[key.Name] {
+		return nil, enforcement.ErrReactivationNotAllowed
+	}
+	f.reactivated = append(f.reactivated, key.String())
+	f.source = source
+	for _, w := range f.paused {
+		if w.Key() == key {
+			w.SetReplicas(2)
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("%s not found", key)
+}
+
+func pausedDeployment(namespace, name string) *workload.Workload {
+	replicas := int32(0)
+	return workload.FromDeployment(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				"finops.io/paused":                    "true",
+				"finops.io/policy":                    "dev-idle-gc",
+				"finops.io/estimated-monthly-savings": "146.00",
+			},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+	})
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		token           string
+		wantStatus      int
+		wantReactivated []string
+		wantEvent       string
+	}{
+		{
+			name:       "missing token",
+			method:     http.MethodGet,
+			path:       "/api/v1/namespaces/dev-team/paused",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid token",
+			method:     http.MethodPost,
+			path:       "/api/v1/namespaces/dev-team/reactivate",
+			token:      "forged",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "list paused",
+			method:     http.MethodGet,
+			path:       "/api/v1/namespaces/dev-team/paused",
+			token:      "valid",
+			wantStatus: http.StatusOK,
+			wantEvent:  "Normal PausedWorkloadsListed",
+		},
+		{
+			name:       "list in forbidden namespace",
+			method:     http.MethodGet,
+			path:       "/api/v1/namespaces/payments/paused",
+			token:      "valid",
+			wantStatus: http.StatusForbidden,
+			wantEvent:  "Warning APIAccessDenied",
+		},
+		{
+			name:            "reactivate one",
+			method:          http.MethodPost,
+			path:            "/api/v1/namespaces/dev-team/deployments/api/reactivate",
+			token:           "valid",
+			wantStatus:      http.StatusOK,
+			wantReactivated: []string{"dev-team/api"},
+			wantEvent:       "Normal Reactivated",
+		},
+		{
+			name:       "reactivate disallowed by policy",
+			method:     http.MethodPost,
+			path:       "/api/v1/namespaces/dev-team/deployments/locked/reactivate",
+			token:      "valid",
+			wantStatus: http.StatusForbidden,
+			wantEvent:  "Warning ReactivationFailed",
+		},
+		{
+			name:       "reactivate in forbidden namespace",
+			method:     http.MethodPost,
+			path:       "/api/v1/namespaces/payments/deployments/billing/reactivate",
+			token:      "valid",
+			wantStatus: http.StatusForbidden,
+			wantEvent:  "Warning APIAccessDenied",
+		},
+		{
+			name:       "reactivate with read-only access",
+			method:     http.MethodPost,
+			path:       "/api/v1/namespaces/qa/deployments/api/reactivate",
+			token:      "valid",
+			wantStatus: http.StatusForbidden,
+			wantEvent:  "Warning APIAccessDenied",
+		},
+		{
+			name:            "reactivate with reactivate access only",
+			method:          http.MethodPost,
+			path:            "/api/v1/namespaces/staging/deployments/api/reactivate",
+			token:           "valid",
+			wantStatus:      http.StatusOK,
+			wantReactivated: []string{"staging/api"},
+			wantEvent:       "Normal Reactivated",
+		},
+		{
+			name:       "list with read-only access",
+			method:     http.MethodGet,
+			path:       "/api/v1/namespaces/qa/paused",
+			token:      "valid",
+			wantStatus: http.StatusOK,
+			wantEvent:  "Normal PausedWorkloadsListed",
+		},
+		{
+			name:            "reactivate namespace",
+			method:          http.MethodPost,
+			path:            "/api/v1/namespaces/dev-team/reactivate",
+			token:           "valid",
+			wantStatus:      http.StatusOK,
+			wantReactivated: []string{"dev-team/api", "dev-team/web"},
+			wantEvent:       "Normal Reactivated",
+		},
+		{
+			name:       "unknown resource",
+			method:     http.MethodPost,
+			path:       "/api/v1/namespaces/dev-team/daemonsets/agent/reactivate",
+			token:      "valid",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workloads := &fakeWorkloads{
+				paused: []*workload.Workload{
+					pausedDeployment("dev-team", "api"),
+					pausedDeployment("dev-team", "web"),
+					pausedDeployment("payments", "billing"),
+					pausedDeployment("qa", "api"),
+					pausedDeployment("staging", "api"),
+				},
+				locked: map[string]bool{"locked": true},
+			}
+			recorder := record.NewFakeRecorder(10)
+			authorizer := fakeAuthorizer{
+				namespaces:   map[string]bool{"dev-team": true},
+				readOnly:     map[string]bool{"qa": true},
+				reactivators: map[string]bool{"staging": true},
+			}
+			handler := NewHandler(authorizer, workloads, recorder)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if strings.Join(workloads.reactivated, ",") != strings.Join(tt.wantReactivated, ",") {
+				t.Errorf("reactivated = %v, want %v", workloads.reactivated, tt.wantReactivated)
+			}
+			if len(tt.wantReactivated) > 0 && workloads.source != "api" {
+				t.Errorf("source = %q, want api", workloads.source)
+			}
+
+			select {
+			case event := <-recorder.Events:
+				if tt.wantEvent == "" || !strings.HasPrefix(event, tt.wantEvent) {
+					t.Errorf("event = %q, want prefix %q", event, tt.wantEvent)
+				}
+			default:
+				if tt.wantEvent != "" {
+					t.Errorf("expected event %q", tt.wantEvent)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerListPausedBody(t *testing.T) {
+	workloads := &fakeWorkloads{paused: []*workload.Workload{pausedDeployment("dev-team", "api")}}
+	handler := NewHandler(fakeAuthorizer{namespaces: map[string]bool{"dev-team": true}}, workloads, record.NewFakeRecorder(10))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/dev-team/paused", nil)
+	req.Header.Set("Authorization", "Bearer valid")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var items []PausedWorkload
+	if err := json.NewDecoder(rec.Body).Decode(&items); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(items) != 1 || items[0].Name != "api" || items[0].EstimatedMonthlySavings != 146 {
+		t.Errorf("items = %+v", items)
+	}
+}
+
+func TestReactivationAttributes(t *testing.T) {
+	for _, kind := range workload.SupportedKinds {
+		t.Run(string(kind), func(t *testing.T) {
+			got := reactivationAttributes(workload.Key{Kind: kind, Namespace: "dev-team", Name: "api"})
+			want := authorizationv1.ResourceAttributes{
+				Namespace: "dev-team",
+				Verb:      "reactivate",
+				Group:     "finops.io",
+				Resource:  "workloads",
+				Name:      "api",
+			}
+			if got != want {
+				t.Errorf("reactivationAttributes() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
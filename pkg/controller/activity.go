@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	"github.com/yourusername/finops-enforcer/pkg/prometheus"
+	"github.com/yourusername/finops-enforcer/pkg/scope"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ActivityTracker periodically samples traffic and utilization of workloads
+// in scope of any EnforcementPolicy or ClusterEnforcementPolicy and stamps finops.io/last-activity on active ones,
+// so that idle windows are measured from observed data
+type ActivityTracker struct {
+	client.Client
+	Traffic     policy.TrafficSource
+	Utilization policy.UtilizationSource
+
+	// Interval is both the sampling period and the window each sample covers
+	Interval time.Duration
+
+	// CPUThreshold is the CPU utilization (percent of requests) above which a
+	// workload counts as active
+	CPUThreshold float64
+}
+
+// Start runs the tracker until the context is cancelled
+func (t *ActivityTracker) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("activity-tracker")
+	logger.Info("starting activity tracker", "interval", t.Interval)
+
+	ticker := time.NewTicker(t.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.Sample(ctx); err != nil {
+			logger.Error(err, "activity sampling failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample checks every workload in scope of a policy once and records activity
+func (t *ActivityTracker) Sample(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("activity-tracker")
+
+	policies, err := listPolicies(ctx, t.Client)
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := scope.ListNamespaces(ctx, t.Client)
+	if err != nil {
+		return err
+	}
+
+	seen := map[workload.Key]bool{}
+	for _, policyObj := range policies {
+		workloads, err := scope.List(ctx, t.Client, policyObj.Spec.Scope, reachedNamespaces(policyObj.Candidate, namespaces))
+		if err != nil {
+			return err
+		}
+
+		for _, w := range workloads {
+			if seen[w.Key()] || w.GetAnnotations()["finops.io/paused"] == "true" {
+				continue
+			}
+			seen[w.Key()] = true
+
+			active, err := t.isActive(ctx, policyObj.EnforcementPolicy, w)
+			if err != nil {
+				logger.Error(err, "failed to sample activity",
+					"kind", w.Kind,
+					"workload", w.GetName(),
+					"namespace", w.GetNamespace(),
+				)
+				continue
+			}
+
+			if !active {
+				continue
+			}
+
+			if err := t.recordActivity(ctx, w); err != nil {
+				logger.Error(err, "failed to record activity",
+					"kind", w.Kind,
+					"workload", w.GetName(),
+					"namespace", w.GetNamespace(),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isActive reports whether a workload served traffic or used CPU during the last interval
+func (t *ActivityTracker) isActive(
+	ctx context.Context,
+	policyObj *finopsv1alpha1.EnforcementPolicy,
+	w *workload.Workload,
+) (bool, error) {
+	if t.Traffic != nil {
+		query := ""
+		if threshold := policyObj.Spec.Conditions.TrafficThreshold; threshold != nil {
+			query = threshold.Query
+		}
+
+		// Workloads without traffic series may still be active by CPU
+		rate, err := t.Traffic.RequestsPerMinute(ctx, query, w, t.Interval)
+		if err != nil && !errors.Is(err, prometheus.ErrNoData) {
+			return false, err
+		}
+
+		if rate > 0 {
+			return true, nil
+		}
+	}
+
+	if t.Utilization != nil && w.Kind.Scalable() {
+		cpu, _, err := t.Utilization.Utilization(ctx, w, t.Interval)
+		if err != nil {
+			return false, err
+		}
+
+		if cpu > t.CPUThreshold {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// recordActivity stamps finops.io/last-activity with the current time
+func (t *ActivityTracker) recordActivity(ctx context.Context, w *workload.Workload) error {
+	patch := client.MergeFrom(w.DeepCopyObject().(client.Object))
+
+	annotations := w.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations["finops.io/last-activity"] = time.Now().Format(time.RFC3339)
+	w.SetAnnotations(annotations)
+
+	if err := t.Patch(ctx, w.Object, patch); err != nil {
+		return fmt.Errorf("failed to patch last-activity: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/prometheus"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeTraffic returns a fixed request rate per workload name, and no data for
+// workloads it does not know
+type fakeTraffic map[string]float64
+
+func (f fakeTraffic) RequestsPerMinute(ctx context.Context, queryTemplate string, w *workload.Workload, window time.Duration) (float64, error) {
+	rate, ok := f[w.GetName()]
+	if !ok {
+		return 0, prometheus.ErrNoData
+	}
+	return rate, nil
+}
+
+// fakeCPU returns a fixed CPU utilization per workload name
+type fakeCPU map[string]float64
+
+func (f fakeCPU) Utilization(ctx context.Context, w *workload.Workload, window time.Duration) (float64, float64, error) {
+	return f[w.GetName()], 0, nil
+}
+
+func TestActivityTrackerSample(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	newDeployment := func(namespace, name string, annotations map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Annotations: annotations},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&finopsv1alpha1.EnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev-idle", Namespace: "dev-team"},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Scope: finopsv1alpha1.ScopeSpec{
+					Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"dev-*"}},
+				},
+			},
+		},
+		&finopsv1alpha1.ClusterEnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "preview-idle"},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Scope: finopsv1alpha1.ScopeSpec{
+					Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"preview-*"}},
+				},
+			},
+		},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-team"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "preview-team"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-other"}},
+		newDeployment("dev-team", "busy", nil),
+		newDeployment("dev-team", "quiet", nil),
+		newDeployment("dev-team", "unmeshed", nil),
+		newDeployment("dev-team", "paused", map[string]string{"finops.io/paused": "true"}),
+		newDeployment("preview-team", "preview", nil),
+		newDeployment("dev-other", "other", nil),
+	).Build()
+
+	tracker := &ActivityTracker{
+		Client:       c,
+		Traffic:      fakeTraffic{"busy": 3, "quiet": 0, "paused": 3, "preview": 3, "other": 3},
+		Utilization:  fakeCPU{"unmeshed": 50},
+		Interval:     10 * time.Minute,
+		CPUThreshold: 5,
+	}
+	if err := tracker.Sample(context.Background()); err != nil {
+		t.Fatalf("Sample() error = %v", err)
+	}
+
+	tests := []struct {
+		namespace string
+		name      string
+		wantSeen  bool
+	}{
+		{namespace: "dev-team", name: "busy", wantSeen: true},
+		{namespace: "dev-team", name: "quiet", wantSeen: false},
+		{namespace: "dev-team", name: "unmeshed", wantSeen: true},
+		{namespace: "dev-team", name: "paused", wantSeen: false},
+		{namespace: "preview-team", name: "preview", wantSeen: true},
+		{namespace: "dev-other", name: "other", wantSeen: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{}
+			if err := c.Get(context.Background(), client.ObjectKey{Namespace: tt.namespace, Name: tt.name}, deployment); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			_, seen := deployment.Annotations["finops.io/last-activity"]
+			if seen != tt.wantSeen {
+				t.Errorf("last-activity set = %v, want %v", seen, tt.wantSeen)
+			}
+		})
+	}
+}
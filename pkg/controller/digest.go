@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/digest"
+	"github.com/yourusername/finops-enforcer/pkg/notifications"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DigestSender periodically sends the daily or weekly digest of every
+// EnforcementPolicy and ClusterEnforcementPolicy with spec.actions.digest set,
+// through the notifiers the policy selects. Each namespace's part of a digest
+// goes to its owners' destinations.
+type DigestSender struct {
+	client.Client
+	Store     *digest.Store
+	Notifiers map[finopsv1alpha1.NotifyType]notifications.Notifier
+	Router    *notifications.Router
+
+	// Interval is how often policies are checked for a finished digest period
+	Interval time.Duration
+}
+
+// Start runs the sender until the context is cancelled. State recorded
+// under bare policy names is migrated once first; digests are not sent until
+// it is, since sending prunes state of unknown policies.
+func (s *DigestSender) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("digest-sender")
+	logger.Info("starting digest sender", "interval", s.Interval)
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	migrated := false
+	for {
+		if !migrated {
+			if err := s.renameLegacyState(ctx); err != nil {
+				logger.Error(err, "migrating digest state failed")
+			} else {
+				migrated = true
+			}
+		}
+
+		if migrated {
+			if err := s.Send(ctx); err != nil {
+				logger.Error(err, "sending digests failed")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// renameLegacyState moves state recorded before policy refs, keyed by bare
+// policy name, to the ref of the digest policy with that name
+func (s *DigestSender) renameLegacyState(ctx context.Context) error {
+	policies, err := listPolicies(ctx, s.Client)
+	if err != nil {
+		return err
+	}
+
+	renames := map[string]string{}
+	for _, policyObj := range policies {
+		if _, seen := renames[policyObj.Name]; !seen && policyObj.Spec.Actions.Digest != "" {
+			renames[policyObj.Name] = policyObj.Candidate.Ref()
+		}
+	}
+	return s.Store.RenamePolicies(ctx, renames)
+}
+
+// Send delivers the digests whose period has ended and drops the state of
+// policies that no longer use digests
+func (s *DigestSender) Send(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("digest-sender")
+
+	policies, err := listPolicies(ctx, s.Client)
+	if err != nil {
+		return err
+	}
+
+	active := map[string]bool{}
+	for _, policyObj := range policies {
+		if policyObj.Spec.Actions.Digest == "" {
+			continue
+		}
+		ref := policyObj.Candidate.Ref()
+		active[ref] = true
+
+		d, err := s.Store.Due(ctx, ref, policyObj.Spec.Actions.Digest)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			continue
+		}
+
+		// Quiet periods are skipped rather than sent as empty summaries
+		if !d.Totals.IsZero() && !s.deliver(ctx, policyObj.Spec.Actions, d) {
+			continue
+		}
+
+		if err := s.Store.Complete(ctx, d); err != nil {
+			return err
+		}
+
+		logger.Info("digest period completed",
+			"policy", d.Policy,
+			"frequency", d.Frequency,
+			"pauses", d.Totals.Pauses,
+			"reactivations", d.Totals.Reactivations,
+			"estimated_savings", d.Totals.EstimatedSavings,
+		)
+	}
+
+	return s.Store.Prune(ctx, active)
+}
+
+// deliver sends a digest through every configured notifier the policy
+// selects, split by the owner route of each namespace. It reports false, so
+// the period is retried, only when every attempted delivery failed.
+func (s *DigestSender) deliver(ctx context.Context, actions finopsv1alpha1.ActionsSpec, d *digest.Digest) bool {
+	logger := log.FromContext(ctx).WithName("digest-sender")
+
+	attempted, delivered := false, false
+	for _, part := range s.routeDigest(ctx, d) {
+		for _, notifyType := range notifyTypes(actions) {
+			notifier, ok := s.Notifiers[notifyType]
+			if !ok {
+				continue
+			}
+			attempted = true
+
+			if err := notifier.NotifyDigest(ctx, part.digest, part.route); err != nil {
+				logger.Error(err, "failed to send digest",
+					"notify", notifyType,
+					"policy", d.Policy,
+					"source", part.route.Source,
+				)
+				continue
+			}
+			delivered = true
+		}
+	}
+
+	return delivered || !attempted
+}
+
+// routedDigest is the part of a digest sent to one route
+type routedDigest struct {
+	route  notifications.Route
+	digest *digest.Digest
+}
+
+// routeDigest splits a digest by the route of each namespace. Namespaces
+// whose routes share destinations are sent together, in namespace order.
+func (s *DigestSender) routeDigest(ctx context.Context, d *digest.Digest) []routedDigest {
+	var keys []string
+	routes := map[string]notifications.Route{}
+	namespaces := map[string][]string{}
+	for _, ns := range d.Namespaces {
+		route := s.Router.RouteNamespace(ctx, ns.Namespace)
+		key := route.SlackChannel + "|" + strings.Join(route.Emails, ",")
+		if _, ok := routes[key]; !ok {
+			keys = append(keys, key)
+			routes[key] = route
+		}
+		namespaces[key] = append(namespaces[key], ns.Namespace)
+	}
+
+	if len(keys) <= 1 {
+		route := notifications.Route{Source: notifications.RouteSourceDefault}
+		if len(keys) == 1 {
+			route = routes[keys[0]]
+		}
+		return []routedDigest{{route: route, digest: d}}
+	}
+
+	parts := make([]routedDigest, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, routedDigest{route: routes[key], digest: d.ForNamespaces(namespaces[key])})
+	}
+	return parts
+}
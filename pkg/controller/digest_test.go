@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/digest"
+	"github.com/yourusername/finops-enforcer/pkg/notifications"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeNotifier records digests and their routes
+type fakeNotifier struct {
+	digests []*digest.Digest
+	routes  []notifications.Route
+}
+
+func (f *fakeNotifier) NotifyPause(ctx context.Context, action *policy.EnforcementAction, route notifications.Route) error {
+	return nil
+}
+
+func (f *fakeNotifier) NotifyReactivation(ctx context.Context, key workload.Key, replicas int32) error {
+	return nil
+}
+
+func (f *fakeNotifier) NotifyDigest(ctx context.Context, d *digest.Digest, route notifications.Route) error {
+	f.digests = append(f.digests, d)
+	f.routes = append(f.routes, route)
+	return nil
+}
+
+func TestDigestSenderSend(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	periodStart := func(ago time.Duration) string {
+		return time.Now().Add(-ago).UTC().Format(time.RFC3339)
+	}
+	state := fmt.Sprintf(`{"policies":{
+		"EnforcementPolicy/finops-system/daily-due":{"periodStart":%q,"namespaces":{"dev-a":{"pauses":2,"estimatedSavings":80}}},
+		"EnforcementPolicy/finops-system/daily-quiet":{"periodStart":%q},
+		"ClusterEnforcementPolicy/cluster-daily":{"periodStart":%q,"namespaces":{"dev-d":{"pauses":3,"estimatedSavings":30}}},
+		"weekly-pending":{"periodStart":%q,"namespaces":{"dev-b":{"pauses":1,"estimatedSavings":10}}},
+		"EnforcementPolicy/finops-system/deleted":{"periodStart":%q,"namespaces":{"dev-c":{"pauses":1}}}
+	}}`, periodStart(25*time.Hour), periodStart(25*time.Hour), periodStart(25*time.Hour), periodStart(48*time.Hour), periodStart(48*time.Hour))
+
+	newPolicy := func(name string, frequency finopsv1alpha1.DigestFrequency) *finopsv1alpha1.EnforcementPolicy {
+		return &finopsv1alpha1.EnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "finops-system"},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Actions: finopsv1alpha1.ActionsSpec{Notify: finopsv1alpha1.NotifyTypeSlack, Digest: frequency},
+			},
+		}
+	}
+
+	key := client.ObjectKey{Namespace: "finops-system", Name: "finops-digest"}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Data:       map[string]string{"state.json": state},
+		},
+		newPolicy("daily-due", finopsv1alpha1.DigestDaily),
+		newPolicy("daily-quiet", finopsv1alpha1.DigestDaily),
+		newPolicy("weekly-pending", finopsv1alpha1.DigestWeekly),
+		&finopsv1alpha1.ClusterEnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-daily"},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Actions: finopsv1alpha1.ActionsSpec{Notify: finopsv1alpha1.NotifyTypeSlack, Digest: finopsv1alpha1.DigestDaily},
+			},
+		},
+	).Build()
+
+	notifier := &fakeNotifier{}
+	store := digest.NewStore(c, c, key)
+	sender := &DigestSender{
+		Client:    c,
+		Store:     store,
+		Notifiers: map[finopsv1alpha1.NotifyType]notifications.Notifier{finopsv1alpha1.NotifyTypeSlack: notifier},
+		Interval:  time.Minute,
+	}
+	if err := sender.renameLegacyState(context.Background()); err != nil {
+		t.Fatalf("renameLegacyState() error = %v", err)
+	}
+	if err := sender.Send(context.Background()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(notifier.digests) != 2 {
+		t.Fatalf("digests sent = %d, want 2", len(notifier.digests))
+	}
+	if got := notifier.digests[0]; got.Policy != "EnforcementPolicy/finops-system/daily-due" || got.Totals.Pauses != 2 {
+		t.Errorf("digest = %+v, want daily-due with 2 pauses", got)
+	}
+	if got := notifier.digests[1]; got.Policy != "ClusterEnforcementPolicy/cluster-daily" || got.Totals.Pauses != 3 {
+		t.Errorf("digest = %+v, want cluster-daily with 3 pauses", got)
+	}
+
+	// The sent period is reset and a second pass sends nothing
+	if err := sender.Send(context.Background()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(notifier.digests) != 2 {
+		t.Errorf("digests sent after reset = %d, want 2", len(notifier.digests))
+	}
+
+	// Deleted policies are pruned
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), key, configMap); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	var persisted digest.State
+	if err := json.Unmarshal([]byte(configMap.Data["state.json"]), &persisted); err != nil {
+		t.Fatalf("invalid state: %v", err)
+	}
+	if _, ok := persisted.Policies["EnforcementPolicy/finops-system/deleted"]; ok {
+		t.Error("state of deleted policy was not pruned")
+	}
+
+	if _, ok := persisted.Policies["ClusterEnforcementPolicy/cluster-daily"]; !ok {
+		t.Error("state of cluster policy was pruned")
+	}
+
+	// State keyed by a bare policy name moves to the policy ref
+	if ps := persisted.Policies["EnforcementPolicy/finops-system/weekly-pending"]; ps == nil || ps.Namespaces["dev-b"].Pauses != 1 {
+		t.Errorf("weekly-pending state = %+v, want pending pause kept", ps)
+	}
+}
+
+func TestDigestSenderRoutesByOwner(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	state := fmt.Sprintf(`{"policies":{"EnforcementPolicy/finops-system/daily":{"periodStart":%q,"namespaces":{
+		"payments-a":{"pauses":1,"estimatedSavings":10},
+		"payments-b":{"pauses":2,"estimatedSavings":20},
+		"unowned":{"pauses":4,"estimatedSavings":40}
+	}}}}`, time.Now().Add(-25*time.Hour).UTC().Format(time.RFC3339))
+
+	key := client.ObjectKey{Namespace: "finops-system", Name: "finops-digest"}
+	owned := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{notifications.OwnerSlackChannelAnnotation: "#payments"},
+		}}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Data:       map[string]string{"state.json": state},
+		},
+		owned("payments-a"),
+		owned("payments-b"),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unowned"}},
+		&finopsv1alpha1.EnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "daily", Namespace: "finops-system"},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Actions: finopsv1alpha1.ActionsSpec{Notify: finopsv1alpha1.NotifyTypeSlack, Digest: finopsv1alpha1.DigestDaily},
+			},
+		},
+	).Build()
+
+	notifier := &fakeNotifier{}
+	sender := &DigestSender{
+		Client:    c,
+		Store:     digest.NewStore(c, c, key),
+		Notifiers: map[finopsv1alpha1.NotifyType]notifications.Notifier{finopsv1alpha1.NotifyTypeSlack: notifier},
+		Router:    notifications.NewRouter(c, client.ObjectKey{Namespace: "finops-system", Name: "finops-owner-routing"}, time.Minute),
+		Interval:  time.Minute,
+	}
+	if err := sender.Send(context.Background()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(notifier.digests) != 2 {
+		t.Fatalf("digests sent = %d, want one per route", len(notifier.digests))
+	}
+	if route, d := notifier.routes[0], notifier.digests[0]; route.SlackChannel != "#payments" ||
+		len(d.Namespaces) != 2 || d.Totals.Pauses != 3 {
+		t.Errorf("owner digest = %+v to %+v, want payments-a and payments-b to #payments", d, route)
+	}
+	if route, d := notifier.routes[1], notifier.digests[1]; route.Source != notifications.RouteSourceDefault ||
+		len(d.Namespaces) != 1 || d.Totals.Pauses != 4 {
+		t.Errorf("default digest = %+v to %+v, want unowned to the defaults", d, route)
+	}
+}
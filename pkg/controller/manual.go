@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/digest"
+	"github.com/yourusername/finops-enforcer/pkg/enforcement"
+	"github.com/yourusername/finops-enforcer/pkg/guardrail"
+	"github.com/yourusername/finops-enforcer/pkg/metrics"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// ManualReactivationReconciler watches paused workloads of one kind and
+// detects when they were restored outside FinOps Enforcer (e.g. kubectl scale)
+type ManualReactivationReconciler struct {
+	client.Client
+	Kind     workload.Kind
+	Enforcer *enforcement.Executor
+	Digest   *digest.Store
+	Guard    *guardrail.Guard
+
+	// FalsePositiveWindow counts manual reactivations within this long of
+	// finops.io/paused-at as false positives
+	FalsePositiveWindow time.Duration
+}
+
+// Reconcile clears the pause bookkeeping of manually restored workloads
+func (r *ManualReactivationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	w, err := workload.Get(ctx, r.Client, workload.Key{
+		Kind:      r.Kind,
+		Namespace: req.Namespace,
+		Name:      req.Name,
+	})
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !isPausedWorkload(w) || !manuallyRestored(w) {
+		return ctrl.Result{}, nil
+	}
+
+	annotations := w.GetAnnotations()
+	policyName := annotations["finops.io/policy"]
+	savings, _ := strconv.ParseFloat(annotations["finops.io/estimated-monthly-savings"], 64)
+	pausedFor, pausedAtKnown := timeSincePaused(annotations["finops.io/paused-at"])
+
+	if err := r.Enforcer.AcknowledgeManualReactivation(ctx, w); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	metrics.RecordReactivation(w.GetNamespace(), policyName, metrics.ReactivationSourceManual, savings)
+	r.Guard.RecordReactivation()
+	if err := r.Digest.RecordReactivation(ctx, policyName, w.GetNamespace(), savings); err != nil {
+		logger.Error(err, "failed to record reactivation in digest", "policy", policyName)
+	}
+	falsePositive := pausedAtKnown && pausedFor < r.FalsePositiveWindow
+	if falsePositive {
+		metrics.RecordFalsePositive(w.GetNamespace(), policyName)
+		r.Guard.RecordFalsePositive()
+		if err := r.Digest.RecordFalsePositive(ctx, policyName, w.GetNamespace()); err != nil {
+			logger.Error(err, "failed to record false positive in digest", "policy", policyName)
+		}
+	}
+
+	logger.Info("detected manual reactivation",
+		"kind", w.Kind,
+		"workload", w.GetName(),
+		"namespace", w.GetNamespace(),
+		"policy", policyName,
+		"paused_for", pausedFor,
+		"false_positive", falsePositive,
+	)
+
+	return ctrl.Result{}, nil
+}
+
+// manuallyRestored reports whether a paused workload is running again
+func manuallyRestored(w *workload.Workload) bool {
+	if w.Kind.Suspendable() {
+		return !w.Suspended()
+	}
+
+	return w.Replicas() > 0
+}
+
+// isPausedWorkload checks the pause annotation
+func isPausedWorkload(obj client.Object) bool {
+	return obj.GetAnnotations()["finops.io/paused"] == "true"
+}
+
+// timeSincePaused parses finops.io/paused-at and returns the elapsed time
+func timeSincePaused(pausedAt string) (time.Duration, bool) {
+	t, err := time.Parse(time.RFC3339, pausedAt)
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Since(t), true
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *ManualReactivationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	w, err := workload.New(r.Kind)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("manual-reactivation-"+strings.ToLower(string(r.Kind))).
+		For(w.Object, builder.WithPredicates(predicate.NewPredicateFuncs(isPausedWorkload))).
+		Complete(r)
+}
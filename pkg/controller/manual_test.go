@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/yourusername/finops-enforcer/pkg/enforcement"
+	"github.com/yourusername/finops-enforcer/pkg/metrics"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestManualReactivationReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name              string
+		replicas          int32
+		pausedAgo         time.Duration
+		wantCleared       bool
+		wantFalsePositive bool
+	}{
+		{name: "still-paused", replicas: 0, pausedAgo: 10 * time.Minute, wantCleared: false},
+		{name: "scaled-soon-after", replicas: 2, pausedAgo: 10 * time.Minute, wantCleared: true, wantFalsePositive: true},
+		{name: "scaled-much-later", replicas: 2, pausedAgo: 48 * time.Hour, wantCleared: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tt.name,
+					Namespace: "dev-manual",
+					Annotations: map[string]string{
+						"finops.io/paused":            "true",
+						"finops.io/paused-at":         time.Now().Add(-tt.pausedAgo).Format(time.RFC3339),
+						"finops.io/policy":            tt.name,
+						"finops.io/original-replicas": "2",
+					},
+				},
+				Spec: appsv1.DeploymentSpec{Replicas: &tt.replicas},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+
+			r := &ManualReactivationReconciler{
+				Client:              c,
+				Kind:                workload.KindDeployment,
+				Enforcer:            enforcement.NewExecutor(c),
+				FalsePositiveWindow: time.Hour,
+			}
+			key := types.NamespacedName{Namespace: "dev-manual", Name: tt.name}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			got := &appsv1.Deployment{}
+			if err := c.Get(context.Background(), client.ObjectKey(key), got); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			_, paused := got.Annotations["finops.io/paused"]
+			if paused == tt.wantCleared {
+				t.Errorf("paused annotation present = %v, want %v", paused, !tt.wantCleared)
+			}
+			if *got.Spec.Replicas != tt.replicas {
+				t.Errorf("replicas = %d, want %d", *got.Spec.Replicas, tt.replicas)
+			}
+
+			falsePositives := testutil.ToFloat64(metrics.FalsePositivesTotal.WithLabelValues("dev-manual", tt.name))
+			if (falsePositives == 1) != tt.wantFalsePositive {
+				t.Errorf("false positives = %v, want recorded = %v", falsePositives, tt.wantFalsePositive)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getPolicy fetches the policy a request refers to. Requests without a
+// namespace are for ClusterEnforcementPolicies, which are reconciled through
+// an EnforcementPolicy view placed in ClusterPolicyNamespace; the returned
+// object is the one whose status is updated.
+func (r *EnforcementPolicyReconciler) getPolicy(
+	ctx context.Context,
+	req ctrl.Request,
+) (*finopsv1alpha1.EnforcementPolicy, policy.Candidate, client.Object, error) {
+	if req.Namespace != "" {
+		policyObj := &finopsv1alpha1.EnforcementPolicy{}
+		if err := r.Get(ctx, req.NamespacedName, policyObj); err != nil {
+			return nil, policy.Candidate{}, nil, err
+		}
+		return policyObj, namespacedCandidate(policyObj), policyObj, nil
+	}
+
+	clusterObj := &finopsv1alpha1.ClusterEnforcementPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, clusterObj); err != nil {
+		return nil, policy.Candidate{}, nil, err
+	}
+
+	return clusterView(clusterObj, r.ClusterPolicyNamespace), clusterCandidate(clusterObj), clusterObj, nil
+}
+
+// clusterView returns an EnforcementPolicy view of a ClusterEnforcementPolicy
+// placed in namespace
+func clusterView(clusterObj *finopsv1alpha1.ClusterEnforcementPolicy, namespace string) *finopsv1alpha1.EnforcementPolicy {
+	policyObj := &finopsv1alpha1.EnforcementPolicy{
+		ObjectMeta: *clusterObj.ObjectMeta.DeepCopy(),
+		Spec:       *clusterObj.Spec.DeepCopy(),
+		Status:     *clusterObj.Status.DeepCopy(),
+	}
+	policyObj.Namespace = namespace
+	return policyObj
+}
+
+// policyView is a listed policy of either kind with its candidate
+type policyView struct {
+	*finopsv1alpha1.EnforcementPolicy
+	Candidate policy.Candidate
+}
+
+// listPolicies lists every EnforcementPolicy followed by every
+// ClusterEnforcementPolicy, the latter as views without a namespace
+func listPolicies(ctx context.Context, c client.Reader) ([]policyView, error) {
+	policies := &finopsv1alpha1.EnforcementPolicyList{}
+	if err := c.List(ctx, policies); err != nil {
+		return nil, fmt.Errorf("failed to list policies: %w", err)
+	}
+
+	clusterPolicies := &finopsv1alpha1.ClusterEnforcementPolicyList{}
+	if err := c.List(ctx, clusterPolicies); err != nil {
+		return nil, fmt.Errorf("failed to list cluster policies: %w", err)
+	}
+
+	views := make([]policyView, 0, len(policies.Items)+len(clusterPolicies.Items))
+	for i := range policies.Items {
+		p := &policies.Items[i]
+		views = append(views, policyView{EnforcementPolicy: p, Candidate: namespacedCandidate(p)})
+	}
+	for i := range clusterPolicies.Items {
+		p := &clusterPolicies.Items[i]
+		views = append(views, policyView{EnforcementPolicy: clusterView(p, ""), Candidate: clusterCandidate(p)})
+	}
+
+	return views, nil
+}
+
+// pruneExpressions drops the compiled expressions of deleted policies from the
+// policy engine
+func (r *EnforcementPolicyReconciler) pruneExpressions(ctx context.Context) error {
+	views, err := listPolicies(ctx, r.Client)
+	if err != nil {
+		return err
+	}
+
+	policies := make([]*finopsv1alpha1.EnforcementPolicy, 0, len(views))
+	for _, view := range views {
+		policies = append(policies, view.EnforcementPolicy)
+	}
+	r.PolicyEngine.RetainExpressions(policies)
+	return nil
+}
+
+// updateStatus writes the status of a policy view back to its object, unless
+// it differs from original only in timestamps
+func (r *EnforcementPolicyReconciler) updateStatus(
+	ctx context.Context,
+	policyObj *finopsv1alpha1.EnforcementPolicy,
+	statusObj client.Object,
+	original *finopsv1alpha1.EnforcementPolicyStatus,
+) error {
+	if !statusChanged(original, &policyObj.Status) {
+		return nil
+	}
+	if clusterObj, ok := statusObj.(*finopsv1alpha1.ClusterEnforcementPolicy); ok {
+		policyObj.Status.DeepCopyInto(&clusterObj.Status)
+	}
+	return r.Status().Update(ctx, statusObj)
+}
+
+// policyCandidates lists every policy competing for enforcement decisions,
+// with self in place of its cached copy. Policies being deleted or with an
+// invalid spec are not enforced and do not compete.
+func (r *EnforcementPolicyReconciler) policyCandidates(
+	ctx context.Context,
+	self policy.Candidate,
+) ([]policy.Candidate, error) {
+	candidates := []policy.Candidate{self}
+
+	policies := &finopsv1alpha1.EnforcementPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		return nil, err
+	}
+	for i := range policies.Items {
+		p := &policies.Items[i]
+		if competes(p.DeletionTimestamp == nil, p.Status.Conditions) {
+			candidates = appendCandidate(candidates, self, namespacedCandidate(p))
+		}
+	}
+
+	clusterPolicies := &finopsv1alpha1.ClusterEnforcementPolicyList{}
+	if err := r.List(ctx, clusterPolicies); err != nil {
+		return nil, err
+	}
+	for i := range clusterPolicies.Items {
+		p := &clusterPolicies.Items[i]
+		if competes(p.DeletionTimestamp == nil, p.Status.Conditions) {
+			candidates = appendCandidate(candidates, self, clusterCandidate(p))
+		}
+	}
+
+	return candidates, nil
+}
+
+// reachedNamespaces returns the namespaces a policy may act in: all of them
+// for a ClusterEnforcementPolicy and only its own for an EnforcementPolicy
+func reachedNamespaces(c policy.Candidate, namespaces map[string]*corev1.Namespace) map[string]*corev1.Namespace {
+	if c.Kind == policy.KindClusterEnforcementPolicy {
+		return namespaces
+	}
+
+	reached := map[string]*corev1.Namespace{}
+	if namespace, ok := namespaces[c.Namespace]; ok {
+		reached[c.Namespace] = namespace
+	}
+	return reached
+}
+
+// competes reports whether a policy takes part in ownership resolution
+func competes(live bool, conditions []metav1.Condition) bool {
+	return live && !meta.IsStatusConditionTrue(conditions, finopsv1alpha1.ConditionInvalidSpec)
+}
+
+// appendCandidate appends c unless it is self
+func appendCandidate(candidates []policy.Candidate, self, c policy.Candidate) []policy.Candidate {
+	if c.Ref() == self.Ref() {
+		return candidates
+	}
+	return append(candidates, c)
+}
+
+// namespacedCandidate describes an EnforcementPolicy for ownership resolution
+func namespacedCandidate(p *finopsv1alpha1.EnforcementPolicy) policy.Candidate {
+	return policy.Candidate{
+		Kind:      policy.KindEnforcementPolicy,
+		Namespace: p.Namespace,
+		Name:      p.Name,
+		Priority:  p.Spec.Priority,
+		Scope:     p.Spec.Scope,
+	}
+}
+
+// clusterCandidate describes a ClusterEnforcementPolicy for ownership resolution
+func clusterCandidate(p *finopsv1alpha1.ClusterEnforcementPolicy) policy.Candidate {
+	return policy.Candidate{
+		Kind:     policy.KindClusterEnforcementPolicy,
+		Name:     p.Name,
+		Priority: p.Spec.Priority,
+		Scope:    p.Spec.Scope,
+	}
+}
+
+// conflictSet counts workloads shared with other policies
+type conflictSet map[string]*finopsv1alpha1.PolicyConflict
+
+// add records a workload shared with another policy, and whether that
+// policy owns it
+func (c conflictSet) add(ref string, yielded bool) {
+	conflict, ok := c[ref]
+	if !ok {
+		conflict = &finopsv1alpha1.PolicyConflict{Policy: ref}
+		c[ref] = conflict
+	}
+	conflict.Workloads++
+	if yielded {
+		conflict.Yielded++
+	}
+}
+
+// list returns the conflicts ordered by policy
+func (c conflictSet) list() []finopsv1alpha1.PolicyConflict {
+	conflicts := make([]finopsv1alpha1.PolicyConflict, 0, len(c))
+	for _, conflict := range c {
+		conflicts = append(conflicts, *conflict)
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Policy < conflicts[j].Policy
+	})
+	return conflicts
+}
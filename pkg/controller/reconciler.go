@@ -1,292 +1,457 @@
 package controller
-package controller
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"time"
 
 	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
 	"github.com/yourusername/finops-enforcer/pkg/cost"
+	"github.com/yourusername/finops-enforcer/pkg/digest"
 	"github.com/yourusername/finops-enforcer/pkg/enforcement"
+	"github.com/yourusername/finops-enforcer/pkg/guardrail"
 	"github.com/yourusername/finops-enforcer/pkg/metrics"
 	"github.com/yourusername/finops-enforcer/pkg/notifications"
 	"github.com/yourusername/finops-enforcer/pkg/policy"
-	appsv1 "k8s.io/api/apps/v1"
+	"github.com/yourusername/finops-enforcer/pkg/scope"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-}		Complete(r)		For(&finopsv1alpha1.EnforcementPolicy{}).	return ctrl.NewControllerManagedBy(mgr).func (r *EnforcementPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {// SetupWithManager sets up the controller with the Manager}	return pattern == value		}		return len(value) >= len(prefix) && value[:len(prefix)] == prefix		prefix := pattern[:len(pattern)-1]	if len(pattern) > 0 && pattern[len(pattern)-1] == '*' {	// Simple suffix match for patterns like "dev-*"		}		return true	if pattern == "*" {func matchWildcard(pattern, value string) bool {// matchWildcard performs simple wildcard matching}	return true	}		}			}				return false			if deployment.Labels[key] != value {		for key, value := range scope.Labels.Match {		// Check required matches		}			}				return false			if deployment.Labels[key] == value {		for key, value := range scope.Labels.Exclude {		// Check exclusions first	if scope.Labels != nil {	// Check label filters if specified	}		}			return false		if matchWildcard(pattern, deployment.Namespace) {	for _, pattern := range scope.Namespaces.Exclude {	// Check namespace exclusions	}		return false	if !namespaceMatches {	}		}			break			namespaceMatches = true		if matchWildcard(pattern, deployment.Namespace) {	for _, pattern := range scope.Namespaces.Include {	namespaceMatches := false	// Check namespace filters) bool {	scope finopsv1alpha1.ScopeSpec,	deployment appsv1.Deployment,func (r *EnforcementPolicyReconciler) matchesScope(// matchesScope checks if a deployment matches policy scope}	return filtered, nil	}		}			filtered = append(filtered, deployment)		if r.matchesScope(deployment, policy.Spec.Scope) {	for _, deployment := range deploymentList.Items {	filtered := []appsv1.Deployment{}	// Filter by namespace scope	}		return nil, fmt.Errorf("failed to list deployments: %w", err)	if err := r.List(ctx, deploymentList); err != nil {	deploymentList := &appsv1.DeploymentList{}	// Get all deployments) ([]appsv1.Deployment, error) {	policy *finopsv1alpha1.EnforcementPolicy,	ctx context.Context,func (r *EnforcementPolicyReconciler) getDeploymentsInScope(// getDeploymentsInScope returns all deployments matching policy scope}	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil	// Requeue after 5 minutes	)		"duration", time.Since(startTime),		"actions_taken", actionsPerformed,		"matched", matchedCount,		"policy", policyObj.Name,	logger.Info("reconciliation complete",	metrics.ReconciliationDuration.Observe(time.Since(startTime).Seconds())	// Record reconciliation duration	}		logger.Error(err, "failed to update policy status")	if err := r.Status().Update(ctx, policyObj); err != nil {	policyObj.Status.EstimatedSavings += totalSavings	policyObj.Status.ActionsPerformed += actionsPerformed	policyObj.Status.MatchedResources = matchedCount	policyObj.Status.LastEvaluationTime = &ctrl.Now()	// Update policy status	}		)			"dry_run", action.DryRun,			"estimated_monthly_savings", action.EstimatedMonthlySavings,			"namespace", action.Deployment.Namespace,			"deployment", action.Deployment.Name,			"action", action.Type,		logger.Info("enforcement action executed",		}			}				)					"deployment", action.Deployment.Name,				logger.Error(err, "failed to send slack notification",			if err := r.Notifier.NotifyPause(ctx, action); err != nil {		if policyObj.Spec.Actions.Notify == finopsv1alpha1.NotifyTypeSlack && r.Notifier != nil {		// Send notification		}			)				action.EstimatedMonthlySavings,				action.Policy,				action.Deployment.Namespace,			metrics.RecordPausedResource(		if !action.DryRun {		metrics.RecordAction(string(action.Type), action.Deployment.Namespace, action.DryRun)		// Record metrics		totalSavings += action.EstimatedMonthlySavings		actionsPerformed++		}			continue			)				"namespace", action.Deployment.Namespace,				"deployment", action.Deployment.Name,			logger.Error(err, "failed to execute action",		if err := r.Enforcer.ExecuteAction(ctx, action); err != nil {	for _, action := range actionsToTake {	totalSavings := 0.0	actionsPerformed := 0	// Execute actions	}		actionsToTake = actionsToTake[:maxActions]		)			"max_allowed", maxActions,			"total_matched", len(actionsToTake),		logger.Info("limiting actions per run",	if len(actionsToTake) > maxActions {	}		maxActions = policyObj.Spec.Enforcement.MaxActionsPerRun	if policyObj.Spec.Enforcement.MaxActionsPerRun > 0 {	maxActions := r.MaxActionsPerRun	// Apply max actions limit	}		)			"reason", result.Reason,			"matched", result.Matched,			"namespace", deployment.Namespace,			"deployment", deployment.Name,		logger.V(1).Info("policy evaluation result",		}			}				actionsToTake = append(actionsToTake, result.Action)			if result.Action != nil {						metrics.RecordPolicyMatch(policyObj.Name, string(policyObj.Spec.Actions.Type))			matchedCount++		if result.Matched {		}			continue			)				"namespace", deployment.Namespace,				"deployment", deployment.Name,			logger.Error(err, "policy evaluation failed",		if err != nil {		result, err := r.PolicyEngine.Evaluate(ctx, policyObj, deployment, costData)		// Evaluate policy		}			continue			metrics.OpenCostAPIErrors.Inc()			)				"namespace", deployment.Namespace,				"deployment", deployment.Name,			logger.Error(err, "failed to get cost data",		if err != nil {		)			policyObj.Spec.Conditions.IdleWindow.Duration,			deployment.Name,			deployment.Namespace, 		costData, err := r.CostClient.GetDeploymentCost(ctx, 		// Get cost data for this deployment		deployment := &deployments[i]	for i := range deployments {	matchedCount := 0	actionsToTake := []*policy.EnforcementAction{}	// Evaluate each deployment against policy	)		"count", len(deployments),		"policy", policyObj.Name,	logger.Info("found deployments in scope",	}		return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil		metrics.PolicyEvaluationErrors.WithLabelValues(policyObj.Name).Inc()		logger.Error(err, "failed to get deployments in scope")	if err != nil {	deployments, err := r.getDeploymentsInScope(ctx, policyObj)	// Get all deployments in scope	}()		metrics.PolicyEvaluationDuration.WithLabelValues(policyObj.Name).Observe(duration)		duration := time.Since(evalStart).Seconds()	defer func() {	evalStart := time.Now()	// Track policy evaluation duration	)		"namespace", policyObj.Namespace,		"policy", policyObj.Name,	logger.Info("reconciling enforcement policy",	}		return ctrl.Result{}, client.IgnoreNotFound(err)	if err := r.Get(ctx, req.NamespacedName, policyObj); err != nil {	policyObj := &finopsv1alpha1.EnforcementPolicy{}	// Fetch the EnforcementPolicy	startTime := time.Now()	logger := log.FromContext(ctx)func (r *EnforcementPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch// +kubebuilder:rbac:groups=finops.io,resources=enforcementpolicies/status,verbs=get;update;patch// +kubebuilder:rbac:groups=finops.io,resources=enforcementpolicies,verbs=get;list;watch;update;patch// Reconcile implements the reconciliation loop}	MaxActionsPerRun int	Notifier      *notifications.SlackNotifier	Enforcer      *enforcement.Executor	PolicyEngine  *policy.Engine	CostClient    *cost.Client	Scheme        *runtime.Scheme	client.Clienttype EnforcementPolicyReconciler struct {// EnforcementPolicyReconciler reconciles an EnforcementPolicy object
\ No newline at end of file
+// EnforcementPolicyReconciler reconciles an EnforcementPolicy object
+type EnforcementPolicyReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	CostClient       cost.CostProvider
+	PolicyEngine     *policy.Engine
+	Enforcer         *enforcement.Executor
+	Notifiers        map[finopsv1alpha1.NotifyType]notifications.Notifier
+	Router           *notifications.Router
+	Digest           *digest.Store
+	Guard            *guardrail.Guard
+	MaxActionsPerRun int
+
+	// ClusterPolicyNamespace is where ClusterEnforcementPolicy calendars are read from
+	ClusterPolicyNamespace string
+}
+
+// Reconcile implements the reconciliation loop
+// +kubebuilder:rbac:groups=finops.io,resources=enforcementpolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=finops.io,resources=enforcementpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=finops.io,resources=clusterenforcementpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=finops.io,resources=clusterenforcementpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+func (r *EnforcementPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	startTime := time.Now()
+
+	// Fetch the EnforcementPolicy or ClusterEnforcementPolicy
+	policyObj, self, statusObj, err := r.getPolicy(ctx, req)
+	if apierrors.IsNotFound(err) {
+		// Deleted; drop its compiled expressions
+		if err := r.pruneExpressions(ctx); err != nil {
+			logger.Error(err, "failed to prune compiled expressions")
+		}
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	original := policyObj.Status.DeepCopy()
+
+	logger.Info("reconciling enforcement policy",
+		"policy", self.Ref(),
+	)
+
+	// Policies with an invalid spec are reported in status, not enforced
+	specReason := "InvalidSchedule"
+	specErr := r.PolicyEngine.ValidateSchedule(ctx, policyObj)
+	if specErr == nil {
+		// Policies admitted before the validating webhook was installed
+		specReason = "ValidationFailed"
+		specErr = policy.ValidateSpec(policyObj).ToAggregate()
+	}
+	if specErr == nil {
+		// Compiled once per generation and reused by every evaluation
+		specReason = "InvalidExpression"
+		_, specErr = r.PolicyEngine.Expressions(policyObj)
+	}
+	setInvalidSpecCondition(policyObj, specReason, specErr)
+	if specErr != nil {
+		logger.Error(specErr, "invalid policy spec", "policy", policyObj.Name)
+		metrics.PolicyEvaluationErrors.WithLabelValues(policyObj.Name).Inc()
+		if err := r.updateStatus(ctx, policyObj, statusObj, original); err != nil {
+			logger.Error(err, "failed to update policy status")
+		}
+		// The policy is reconciled again once its spec is fixed
+		return ctrl.Result{}, nil
+	}
+
+	// Restore workloads whose scheduled wake-up or TTL is due
+	requeueAfter := 5 * time.Minute
+	wakeAfter, err := r.wakeUp(ctx, policyObj, self, time.Now())
+	if err != nil {
+		logger.Error(err, "failed to run scheduled reactivation", "policy", policyObj.Name)
+	}
+	if wakeAfter > 0 && wakeAfter < requeueAfter {
+		requeueAfter = wakeAfter
+	}
+
+	active, inactiveReason, err := r.PolicyEngine.ScheduleActive(ctx, policyObj)
+	if err != nil {
+		logger.Error(err, "failed to check policy schedule", "policy", policyObj.Name)
+	} else {
+		setScheduleCondition(policyObj, active, inactiveReason)
+	}
+
+	// Track policy evaluation duration
+	evalStart := time.Now()
+	defer func() {
+		duration := time.Since(evalStart).Seconds()
+		metrics.PolicyEvaluationDuration.WithLabelValues(policyObj.Name).Observe(duration)
+	}()
+
+	// Get all workloads in scope, and the policies competing for them
+	namespaces, err := scope.ListNamespaces(ctx, r.Client)
+	var workloads []*workload.Workload
+	if err == nil {
+		workloads, err = scope.List(ctx, r.Client, policyObj.Spec.Scope, reachedNamespaces(self, namespaces))
+	}
+	var candidates []policy.Candidate
+	if err == nil {
+		candidates, err = r.policyCandidates(ctx, self)
+	}
+	if err != nil {
+		logger.Error(err, "failed to get workloads in scope")
+		metrics.PolicyEvaluationErrors.WithLabelValues(policyObj.Name).Inc()
+		setCondition(policyObj, finopsv1alpha1.ConditionReady, false, "ListFailed", "failed to list workloads: "+err.Error())
+		setCondition(policyObj, finopsv1alpha1.ConditionDegraded, true, "ListFailed", "failed to list workloads: "+err.Error())
+		if err := r.updateStatus(ctx, policyObj, statusObj, original); err != nil {
+			logger.Error(err, "failed to update policy status")
+		}
+		return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+	}
+
+	logger.Info("found workloads in scope",
+		"policy", policyObj.Name,
+		"count", len(workloads),
+	)
+
+	// Evaluate each workload against policy
+	actionsToTake := []*policy.EnforcementAction{}
+	outcome := &reconcileOutcome{evaluated: len(workloads)}
+	conflicts := conflictSet{}
+	decidedAt := metav1.Now()
+	for _, w := range workloads {
+		// Exactly one policy owns the decision for each workload
+		if owner, others, ok := policy.Owner(candidates, w, namespaces[w.GetNamespace()]); ok {
+			for _, other := range append([]policy.Candidate{owner}, others...) {
+				if other.Ref() != self.Ref() {
+					conflicts.add(other.Ref(), other.Ref() == owner.Ref())
+				}
+			}
+			if owner.Ref() != self.Ref() {
+				outcome.yielded++
+				outcome.decide(w, false, "owned by "+owner.Ref(), decidedAt)
+				continue
+			}
+		}
+
+		// Rule out paused, excluded and out-of-scope workloads before
+		// fetching cost data
+		result, err := r.PolicyEngine.Screen(ctx, policyObj, w)
+		if err == nil && result == nil {
+			costData, costErr := r.CostClient.GetWorkloadCost(ctx,
+				w.Key(),
+				policyObj.Spec.Conditions.IdleWindow.Duration,
+			)
+			if errors.Is(costErr, cost.ErrNoCostData) {
+				// Nothing allocated yet, e.g. a new workload; not a backend failure
+				logger.V(1).Info("no cost data",
+					"kind", w.Kind,
+					"workload", w.GetName(),
+					"namespace", w.GetNamespace(),
+				)
+				outcome.decide(w, false, "no cost data", decidedAt)
+				continue
+			}
+			if costErr != nil {
+				logger.Error(costErr, "failed to get cost data",
+					"kind", w.Kind,
+					"workload", w.GetName(),
+					"namespace", w.GetNamespace(),
+				)
+				metrics.OpenCostAPIErrors.Inc()
+				outcome.costErrors++
+				outcome.decide(w, false, "cost data unavailable: "+costErr.Error(), decidedAt)
+				continue
+			}
+
+			result, err = r.PolicyEngine.Evaluate(ctx, policyObj, w, costData)
+		}
+		if err != nil {
+			logger.Error(err, "policy evaluation failed",
+				"kind", w.Kind,
+				"workload", w.GetName(),
+				"namespace", w.GetNamespace(),
+			)
+			outcome.evalErrors++
+			outcome.decide(w, false, "evaluation failed: "+err.Error(), decidedAt)
+			continue
+		}
+		outcome.decide(w, result.Matched, result.Reason, decidedAt)
+
+		// Cluster policies are evaluated through a namespaced view, so the
+		// action is attributed to the reconciled object
+		if result.Action != nil {
+			result.Action.Policy = self.Ref()
+		}
+
+		if result.CancelScheduledPause {
+			if err := r.Enforcer.CancelScheduledPause(ctx, w); err != nil {
+				logger.Error(err, "failed to cancel scheduled pause",
+					"kind", w.Kind,
+					"workload", w.GetName(),
+					"namespace", w.GetNamespace(),
+				)
+			}
+		}
+
+		// Come back when a warning period ends
+		if result.PauseScheduledAt != nil {
+			if until := time.Until(*result.PauseScheduledAt); until < requeueAfter {
+				requeueAfter = max(until, time.Second)
+			}
+		}
+
+		if result.Matched {
+			outcome.matched++
+			metrics.RecordPolicyMatch(policyObj.Name, string(policyObj.Spec.Actions.Type))
+			if result.Action != nil {
+				actionsToTake = append(actionsToTake, result.Action)
+			}
+		}
+
+		logger.V(1).Info("policy evaluation result",
+			"kind", w.Kind,
+			"workload", w.GetName(),
+			"namespace", w.GetNamespace(),
+			"matched", result.Matched,
+			"reason", result.Reason,
+		)
+	}
+
+	// The max actions limit counts the pauses and suspends executed this run,
+	// after the circuit breaker and action budget; warnings are not counted
+	maxActions := r.MaxActionsPerRun
+	if policyObj.Spec.Enforcement.MaxActionsPerRun > 0 {
+		maxActions = policyObj.Spec.Enforcement.MaxActionsPerRun
+	}
+
+	// The circuit breaker halts enforcement cluster-wide; warnings and dry
+	// runs change nothing and are not limited
+	breakerOpen, breakerReason, breakerMessage := r.Guard.Open()
+	setCircuitBreakerCondition(policyObj, breakerOpen, breakerReason, breakerMessage)
+
+	// Execute actions
+	actionsPerformed, actionsLimited := 0, 0
+	for _, action := range actionsToTake {
+		if !action.DryRun && !action.Warning {
+			if breakerOpen {
+				logger.Info("circuit breaker open, skipping action",
+					"kind", action.Workload.Kind,
+					"workload", action.Workload.GetName(),
+					"namespace", action.Workload.GetNamespace(),
+					"reason", breakerReason,
+				)
+				continue
+			}
+			if allowed, scope := r.Guard.Allow(action.Workload.GetNamespace()); !allowed {
+				logger.Info("action budget exhausted, deferring action",
+					"kind", action.Workload.Kind,
+					"workload", action.Workload.GetName(),
+					"namespace", action.Workload.GetNamespace(),
+					"scope", scope,
+				)
+				continue
+			}
+		}
+		if !action.Warning && actionsPerformed >= maxActions {
+			actionsLimited++
+			continue
+		}
+
+		if err := r.Enforcer.ExecuteAction(ctx, action); err != nil {
+			logger.Error(err, "failed to execute action",
+				"kind", action.Workload.Kind,
+				"workload", action.Workload.GetName(),
+				"namespace", action.Workload.GetNamespace(),
+			)
+			outcome.actionErrors++
+			continue
+		}
+
+		// Warnings only notify; the action runs when the warning period ends
+		if action.Warning {
+			r.notify(ctx, policyObj.Spec.Actions, action)
+			logger.Info("pause scheduled",
+				"kind", action.Workload.Kind,
+				"workload", action.Workload.GetName(),
+				"namespace", action.Workload.GetNamespace(),
+				"scheduled_at", action.ScheduledAt,
+				"dry_run", action.DryRun,
+			)
+			continue
+		}
+
+		actionsPerformed++
+
+		// Record metrics
+		metrics.RecordAction(string(action.Type), action.Workload.GetNamespace(), action.DryRun)
+		if !action.DryRun {
+			r.Guard.RecordPause(action.Workload.GetNamespace())
+			metrics.RecordPausedResource(
+				action.Workload.GetNamespace(),
+				action.Policy,
+				action.EstimatedMonthlySavings,
+			)
+			if policyObj.Spec.Actions.Digest != "" {
+				if err := r.Digest.RecordPause(ctx, action.Policy, action.Workload.GetNamespace(), action.EstimatedMonthlySavings); err != nil {
+					logger.Error(err, "failed to record pause in digest", "policy", action.Policy)
+				}
+			}
+		}
+
+		// Send notifications, unless the policy only sends digests
+		if policyObj.Spec.Actions.Digest == "" {
+			r.notify(ctx, policyObj.Spec.Actions, action)
+		}
+
+		logger.Info("enforcement action executed",
+			"action", action.Type,
+			"kind", action.Workload.Kind,
+			"workload", action.Workload.GetName(),
+			"namespace", action.Workload.GetNamespace(),
+			"estimated_monthly_savings", action.EstimatedMonthlySavings,
+			"dry_run", action.DryRun,
+		)
+	}
+	if actionsLimited > 0 {
+		logger.Info("limiting actions per run",
+			"deferred", actionsLimited,
+			"max_allowed", maxActions,
+		)
+	}
+
+	// Update policy status; savings are recomputed from the workloads that
+	// are still paused, so restored workloads no longer count
+	now := metav1.Now()
+	policyObj.Status.LastEvaluationTime = &now
+	policyObj.Status.MatchedResources = outcome.matched
+	policyObj.Status.ActionsPerformed += actionsPerformed
+	policyObj.Status.Conflicts = conflicts.list()
+	setConflictingCondition(policyObj)
+	if paused, err := r.pausedByPolicy(ctx, self); err != nil {
+		logger.Error(err, "failed to list paused workloads", "policy", policyObj.Name)
+	} else {
+		policyObj.Status.PausedResources = len(paused)
+		policyObj.Status.EstimatedSavings = pausedSavings(paused)
+	}
+	setOutcomeConditions(policyObj, outcome)
+	if err := r.updateStatus(ctx, policyObj, statusObj, original); err != nil {
+		logger.Error(err, "failed to update policy status")
+	}
+
+	// Record reconciliation duration
+	metrics.ReconciliationDuration.Observe(time.Since(startTime).Seconds())
+	logger.Info("reconciliation complete",
+		"policy", policyObj.Name,
+		"matched", outcome.matched,
+		"actions_taken", actionsPerformed,
+		"duration", time.Since(startTime),
+	)
+
+	// Requeue after 5 minutes, or sooner when a warning period ends or a
+	// staggered reactivation is pending
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// notify sends a pause notification through every notifier the policy selects
+func (r *EnforcementPolicyReconciler) notify(ctx context.Context, actions finopsv1alpha1.ActionsSpec, action *policy.EnforcementAction) {
+	logger := log.FromContext(ctx)
+
+	types := notifyTypes(actions)
+	if len(types) == 0 {
+		return
+	}
+
+	route := r.Router.Route(ctx, action.Workload)
+	logger.Info("routing notification",
+		"kind", action.Workload.Kind,
+		"workload", action.Workload.GetName(),
+		"namespace", action.Workload.GetNamespace(),
+		"team", route.Team,
+		"slack_channel", route.SlackChannel,
+		"emails", route.Emails,
+		"source", route.Source,
+	)
+
+	for _, notifyType := range types {
+		notifier, ok := r.Notifiers[notifyType]
+		if !ok {
+			logger.V(1).Info("notifier not configured, skipping", "notify", notifyType)
+			continue
+		}
+
+		if err := notifier.NotifyPause(ctx, action, route); err != nil {
+			logger.Error(err, "failed to send notification",
+				"notify", notifyType,
+				"kind", action.Workload.Kind,
+				"workload", action.Workload.GetName(),
+			)
+		}
+	}
+}
+
+// notifyTypes returns the distinct notification methods of a policy, without "none"
+func notifyTypes(actions finopsv1alpha1.ActionsSpec) []finopsv1alpha1.NotifyType {
+	var types []finopsv1alpha1.NotifyType
+	seen := map[finopsv1alpha1.NotifyType]bool{}
+	for _, t := range append([]finopsv1alpha1.NotifyType{actions.Notify}, actions.Notifiers...) {
+		if t == "" || t == finopsv1alpha1.NotifyTypeNone || seen[t] {
+			continue
+		}
+		seen[t] = true
+		types = append(types, t)
+	}
+
+	return types
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *EnforcementPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Status updates bump neither generation, so they do not requeue the policy
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&finopsv1alpha1.EnforcementPolicy{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Cluster policies are enqueued without a namespace
+		Watches(&finopsv1alpha1.ClusterEnforcementPolicy{}, &handler.EnqueueRequestForObject{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
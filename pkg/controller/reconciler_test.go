@@ -0,0 +1,472 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/cost"
+	"github.com/yourusername/finops-enforcer/pkg/enforcement"
+	"github.com/yourusername/finops-enforcer/pkg/guardrail"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNotifyTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions finopsv1alpha1.ActionsSpec
+		want    []finopsv1alpha1.NotifyType
+	}{
+		{
+			name:    "single",
+			actions: finopsv1alpha1.ActionsSpec{Notify: finopsv1alpha1.NotifyTypeSlack},
+			want:    []finopsv1alpha1.NotifyType{finopsv1alpha1.NotifyTypeSlack},
+		},
+		{
+			name:    "none",
+			actions: finopsv1alpha1.ActionsSpec{Notify: finopsv1alpha1.NotifyTypeNone},
+			want:    nil,
+		},
+		{
+			name: "additional notifiers deduplicated",
+			actions: finopsv1alpha1.ActionsSpec{
+				Notify: finopsv1alpha1.NotifyTypeSlack,
+				Notifiers: []finopsv1alpha1.NotifyType{
+					finopsv1alpha1.NotifyTypeTeams,
+					finopsv1alpha1.NotifyTypeSlack,
+					finopsv1alpha1.NotifyTypeEmail,
+				},
+			},
+			want: []finopsv1alpha1.NotifyType{
+				finopsv1alpha1.NotifyTypeSlack,
+				finopsv1alpha1.NotifyTypeTeams,
+				finopsv1alpha1.NotifyTypeEmail,
+			},
+		},
+		{
+			name: "notifiers only",
+			actions: finopsv1alpha1.ActionsSpec{
+				Notify:    finopsv1alpha1.NotifyTypeNone,
+				Notifiers: []finopsv1alpha1.NotifyType{finopsv1alpha1.NotifyTypeWebhook},
+			},
+			want: []finopsv1alpha1.NotifyType{finopsv1alpha1.NotifyTypeWebhook},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notifyTypes(tt.actions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("notifyTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileInvalidSchedule(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	policyObj := &finopsv1alpha1.EnforcementPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "bad-schedule", Namespace: "finops-system"},
+		Spec: finopsv1alpha1.EnforcementPolicySpec{
+			Schedule: &finopsv1alpha1.ScheduleSpec{
+				Timezone: "Mars/Olympus_Mons",
+				Windows:  []finopsv1alpha1.ScheduleWindow{{Start: "22:00", End: "06:00"}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(policyObj).
+		WithStatusSubresource(policyObj).
+		Build()
+
+	// Workloads are never listed, so no cost client is needed
+	r := &EnforcementPolicyReconciler{Client: c, Scheme: scheme, PolicyEngine: policy.NewEngine()}
+	key := types.NamespacedName{Namespace: "finops-system", Name: "bad-schedule"}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	got := &finopsv1alpha1.EnforcementPolicy{}
+	if err := c.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	for _, want := range []struct {
+		conditionType string
+		status        metav1.ConditionStatus
+		reason        string
+	}{
+		{finopsv1alpha1.ConditionInvalidSpec, metav1.ConditionTrue, "InvalidSchedule"},
+		{finopsv1alpha1.ConditionReady, metav1.ConditionFalse, "InvalidSpec"},
+	} {
+		condition := meta.FindStatusCondition(got.Status.Conditions, want.conditionType)
+		if condition == nil {
+			t.Fatalf("condition %s not set", want.conditionType)
+		}
+		if condition.Status != want.status || condition.Reason != want.reason {
+			t.Errorf("condition %s = %s/%s, want %s/%s",
+				want.conditionType, condition.Status, condition.Reason, want.status, want.reason)
+		}
+	}
+}
+
+func TestReconcileClusterPolicyYieldsToNamespaceOverride(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	spec := func(include string) finopsv1alpha1.EnforcementPolicySpec {
+		return finopsv1alpha1.EnforcementPolicySpec{
+			Scope: finopsv1alpha1.ScopeSpec{
+				Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{include}},
+			},
+			Conditions: finopsv1alpha1.ConditionsSpec{IdleWindow: metav1.Duration{Duration: time.Hour}},
+			Actions:    finopsv1alpha1.ActionsSpec{Type: finopsv1alpha1.ActionTypeScaleToZero},
+		}
+	}
+	clusterPolicy := &finopsv1alpha1.ClusterEnforcementPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults"},
+		Spec:       spec("*"),
+	}
+	override := &finopsv1alpha1.EnforcementPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "dev-a"},
+		Spec:       spec("dev-a"),
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-a"}}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-a"}}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(clusterPolicy, override, namespace, deployment).
+		WithStatusSubresource(clusterPolicy, override).
+		Build()
+
+	// The only workload is owned by the override, so no cost data is fetched
+	r := &EnforcementPolicyReconciler{
+		Client:       c,
+		Scheme:       scheme,
+		PolicyEngine: policy.NewEngine(),
+		Enforcer:     enforcement.NewExecutor(c),
+	}
+	key := types.NamespacedName{Name: "defaults"}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	got := &finopsv1alpha1.ClusterEnforcementPolicy{}
+	if err := c.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	wantConflicts := []finopsv1alpha1.PolicyConflict{{Policy: "EnforcementPolicy/dev-a/team-a", Workloads: 1, Yielded: 1}}
+	if !reflect.DeepEqual(got.Status.Conflicts, wantConflicts) {
+		t.Errorf("Conflicts = %+v, want %+v", got.Status.Conflicts, wantConflicts)
+	}
+	if len(got.Status.Decisions) != 1 || got.Status.Decisions[0].Reason != "owned by EnforcementPolicy/dev-a/team-a" {
+		t.Errorf("Decisions = %+v, want owned by the override", got.Status.Decisions)
+	}
+	for _, conditionType := range []string{finopsv1alpha1.ConditionReady, finopsv1alpha1.ConditionConflicting} {
+		if !meta.IsStatusConditionTrue(got.Status.Conditions, conditionType) {
+			t.Errorf("condition %s not true", conditionType)
+		}
+	}
+}
+
+func TestReconcileEnforcementPolicyStaysInItsNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	policyObj := &finopsv1alpha1.EnforcementPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev-idle", Namespace: "finops-system"},
+		Spec: finopsv1alpha1.EnforcementPolicySpec{
+			Scope: finopsv1alpha1.ScopeSpec{
+				Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"dev-*"}},
+			},
+			Conditions: finopsv1alpha1.ConditionsSpec{IdleWindow: metav1.Duration{Duration: time.Hour}},
+			Actions:    finopsv1alpha1.ActionsSpec{Type: finopsv1alpha1.ActionTypeScaleToZero},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			policyObj,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "finops-system"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-a"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-a"}},
+		).
+		WithStatusSubresource(policyObj).
+		Build()
+
+	// Workloads in other namespaces are never listed, so no cost data is fetched
+	r := &EnforcementPolicyReconciler{
+		Client:       c,
+		Scheme:       scheme,
+		PolicyEngine: policy.NewEngine(),
+		Enforcer:     enforcement.NewExecutor(c),
+	}
+	key := types.NamespacedName{Namespace: "finops-system", Name: "dev-idle"}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	got := &finopsv1alpha1.EnforcementPolicy{}
+	if err := c.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(got.Status.Decisions) != 0 {
+		t.Errorf("Decisions = %+v, want none outside finops-system", got.Status.Decisions)
+	}
+}
+
+// fakeCosts serves no cost data and records which workloads were looked up
+type fakeCosts struct {
+	lookups []string
+}
+
+func (f *fakeCosts) GetWorkloadCost(ctx context.Context, key workload.Key, window time.Duration) (*cost.CostData, error) {
+	f.lookups = append(f.lookups, key.Name)
+	return nil, fmt.Errorf("%w for %s", cost.ErrNoCostData, key)
+}
+
+func (f *fakeCosts) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func TestReconcileLooksUpCostLast(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	policyObj := &finopsv1alpha1.EnforcementPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "idle", Namespace: "dev-a"},
+		Spec: finopsv1alpha1.EnforcementPolicySpec{
+			Scope: finopsv1alpha1.ScopeSpec{
+				Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"dev-a"}},
+			},
+			Conditions: finopsv1alpha1.ConditionsSpec{IdleWindow: metav1.Duration{Duration: time.Hour}},
+			Actions:    finopsv1alpha1.ActionsSpec{Type: finopsv1alpha1.ActionTypeScaleToZero},
+		},
+	}
+	newDeployment := func(name string, annotations map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "dev-a", Annotations: annotations}}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			policyObj,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-a"}},
+			newDeployment("paused", map[string]string{"finops.io/paused": "true"}),
+			newDeployment("excluded", map[string]string{"finops.io/exclude": "true"}),
+			newDeployment("new", nil),
+		).
+		WithStatusSubresource(policyObj).
+		Build()
+
+	costs := &fakeCosts{}
+	r := &EnforcementPolicyReconciler{
+		Client:       c,
+		Scheme:       scheme,
+		CostClient:   costs,
+		PolicyEngine: policy.NewEngine(),
+		Enforcer:     enforcement.NewExecutor(c),
+	}
+	key := types.NamespacedName{Namespace: "dev-a", Name: "idle"}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	// Paused and excluded workloads are ruled out before their cost is fetched
+	if want := []string{"new"}; !reflect.DeepEqual(costs.lookups, want) {
+		t.Errorf("cost lookups = %v, want %v", costs.lookups, want)
+	}
+
+	got := &finopsv1alpha1.EnforcementPolicy{}
+	if err := c.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	reasons := map[string]string{}
+	for _, decision := range got.Status.Decisions {
+		reasons[decision.Resource] = decision.Reason
+	}
+	wantReasons := map[string]string{
+		"Deployment/dev-a/paused":   "already paused",
+		"Deployment/dev-a/excluded": "excluded by annotation",
+		"Deployment/dev-a/new":      "no cost data",
+	}
+	if !reflect.DeepEqual(reasons, wantReasons) {
+		t.Errorf("decision reasons = %v, want %v", reasons, wantReasons)
+	}
+
+	// Missing cost data is not a cost source failure
+	for _, conditionType := range []string{finopsv1alpha1.ConditionCostSourceUnavailable, finopsv1alpha1.ConditionDegraded} {
+		if meta.IsStatusConditionTrue(got.Status.Conditions, conditionType) {
+			t.Errorf("condition %s is true, want false", conditionType)
+		}
+	}
+}
+
+// fixedCosts reports the same hourly cost for every workload
+type fixedCosts struct {
+	hourly float64
+}
+
+func (f *fixedCosts) GetWorkloadCost(ctx context.Context, key workload.Key, window time.Duration) (*cost.CostData, error) {
+	return &cost.CostData{Namespace: key.Namespace, Deployment: key.Name, HourlyCost: f.hourly}, nil
+}
+
+func (f *fixedCosts) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func TestReconcileLimitsActionsAfterBudget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	dryRun := false
+	clusterPolicy := &finopsv1alpha1.ClusterEnforcementPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults"},
+		Spec: finopsv1alpha1.EnforcementPolicySpec{
+			Scope: finopsv1alpha1.ScopeSpec{
+				Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"dev-*"}},
+			},
+			Conditions:  finopsv1alpha1.ConditionsSpec{IdleWindow: metav1.Duration{Duration: time.Hour}},
+			Actions:     finopsv1alpha1.ActionsSpec{Type: finopsv1alpha1.ActionTypeScaleToZero},
+			Enforcement: finopsv1alpha1.EnforcementSpec{DryRun: &dryRun, MaxActionsPerRun: 2},
+		},
+	}
+	objects := []client.Object{clusterPolicy}
+	for _, ns := range []string{"dev-a", "dev-b", "dev-c"} {
+		objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+	}
+	for _, key := range []types.NamespacedName{
+		{Namespace: "dev-a", Name: "api"},
+		{Namespace: "dev-a", Name: "web"},
+		{Namespace: "dev-b", Name: "api"},
+		{Namespace: "dev-c", Name: "api"},
+	} {
+		replicas := int32(1)
+		objects = append(objects, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		})
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(clusterPolicy).
+		Build()
+
+	// One pause per namespace: the second dev-a workload is deferred by the
+	// budget and must not use up a slot of the per-run limit
+	r := &EnforcementPolicyReconciler{
+		Client:       c,
+		Scheme:       scheme,
+		CostClient:   &fixedCosts{hourly: 1},
+		PolicyEngine: policy.NewEngine(),
+		Enforcer:     enforcement.NewExecutor(c),
+		Guard:        guardrail.NewGuard(guardrail.Config{MaxActionsPerNamespacePerHour: 1}),
+	}
+	key := types.NamespacedName{Name: "defaults"}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := c.List(context.Background(), deployments); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	pausedPerNamespace := map[string]int{}
+	paused := 0
+	for _, d := range deployments.Items {
+		if d.Annotations["finops.io/paused"] == "true" {
+			pausedPerNamespace[d.Namespace]++
+			paused++
+		}
+	}
+	if paused != 2 {
+		t.Errorf("paused = %d (%v), want the per-run limit of 2", paused, pausedPerNamespace)
+	}
+	if pausedPerNamespace["dev-a"] > 1 {
+		t.Errorf("paused in dev-a = %d, want at most 1 within the namespace budget", pausedPerNamespace["dev-a"])
+	}
+
+	got := &finopsv1alpha1.ClusterEnforcementPolicy{}
+	if err := c.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Status.ActionsPerformed != 2 {
+		t.Errorf("ActionsPerformed = %d, want 2", got.Status.ActionsPerformed)
+	}
+}
+
+func TestReconcileSkipsTimestampOnlyStatusUpdate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	policyObj := &finopsv1alpha1.EnforcementPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "idle", Namespace: "dev-a"},
+		Spec: finopsv1alpha1.EnforcementPolicySpec{
+			Scope: finopsv1alpha1.ScopeSpec{
+				Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"dev-a"}},
+			},
+			Conditions: finopsv1alpha1.ConditionsSpec{IdleWindow: metav1.Duration{Duration: time.Hour}},
+			Actions:    finopsv1alpha1.ActionsSpec{Type: finopsv1alpha1.ActionTypeScaleToZero},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			policyObj,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-a"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Name: "api", Namespace: "dev-a", Annotations: map[string]string{"finops.io/paused": "true"},
+			}},
+		).
+		WithStatusSubresource(policyObj).
+		Build()
+
+	// The only workload is paused, so no cost data is fetched
+	r := &EnforcementPolicyReconciler{
+		Client:       c,
+		Scheme:       scheme,
+		PolicyEngine: policy.NewEngine(),
+		Enforcer:     enforcement.NewExecutor(c),
+	}
+	key := types.NamespacedName{Namespace: "dev-a", Name: "idle"}
+	resourceVersion := func() string {
+		got := &finopsv1alpha1.EnforcementPolicy{}
+		if err := c.Get(context.Background(), key, got); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		return got.ResourceVersion
+	}
+
+	before := resourceVersion()
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	first := resourceVersion()
+	if first == before {
+		t.Fatal("first reconcile did not update status")
+	}
+
+	// Only the evaluation and decision timestamps change
+	time.Sleep(time.Second)
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if second := resourceVersion(); second != first {
+		t.Errorf("resourceVersion = %s after an unchanged reconcile, want %s", second, first)
+	}
+}
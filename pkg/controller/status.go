@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxDecisionRecords bounds status.decisions
+const maxDecisionRecords = 20
+
+// reconcileOutcome collects what happened during one reconcile for status
+type reconcileOutcome struct {
+	evaluated    int
+	matched      int
+	yielded      int
+	costErrors   int
+	evalErrors   int
+	actionErrors int
+	decisions    []finopsv1alpha1.DecisionRecord
+}
+
+// decide records the decision for one workload
+func (o *reconcileOutcome) decide(w *workload.Workload, matched bool, reason string, now metav1.Time) {
+	o.decisions = append(o.decisions, finopsv1alpha1.DecisionRecord{
+		Resource:  fmt.Sprintf("%s/%s/%s", w.Kind, w.GetNamespace(), w.GetName()),
+		Matched:   matched,
+		Reason:    reason,
+		Timestamp: now,
+	})
+}
+
+// setCondition sets a condition on the policy status for its current generation
+func setCondition(policyObj *finopsv1alpha1.EnforcementPolicy, conditionType string, status bool, reason, message string) {
+	conditionStatus := metav1.ConditionFalse
+	if status {
+		conditionStatus = metav1.ConditionTrue
+	}
+
+	meta.SetStatusCondition(&policyObj.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: policyObj.Generation,
+	})
+}
+
+// setInvalidSpecCondition records the spec validation result; an invalid spec
+// also makes the policy not ready
+func setInvalidSpecCondition(policyObj *finopsv1alpha1.EnforcementPolicy, reason string, specErr error) {
+	if specErr == nil {
+		setCondition(policyObj, finopsv1alpha1.ConditionInvalidSpec, false, "Valid", "spec is valid")
+		return
+	}
+
+	setCondition(policyObj, finopsv1alpha1.ConditionInvalidSpec, true, reason, specErr.Error())
+	setCondition(policyObj, finopsv1alpha1.ConditionReady, false, "InvalidSpec", "policy is not enforced: "+specErr.Error())
+}
+
+// setScheduleCondition records whether the policy schedule is currently active
+func setScheduleCondition(policyObj *finopsv1alpha1.EnforcementPolicy, active bool, reason string) {
+	switch {
+	case policyObj.Spec.Schedule == nil:
+		setCondition(policyObj, finopsv1alpha1.ConditionScheduleInactive, false, "NoSchedule", "policy is always active")
+	case active:
+		setCondition(policyObj, finopsv1alpha1.ConditionScheduleInactive, false, "WithinSchedule", "schedule is active")
+	default:
+		setCondition(policyObj, finopsv1alpha1.ConditionScheduleInactive, true, "OutsideSchedule", reason)
+	}
+}
+
+// setCircuitBreakerCondition records the circuit breaker state in policy status
+func setCircuitBreakerCondition(policyObj *finopsv1alpha1.EnforcementPolicy, open bool, reason, message string) {
+	if !open {
+		setCondition(policyObj, finopsv1alpha1.ConditionCircuitBreakerOpen, false, "Closed", "enforcement is not halted")
+		return
+	}
+
+	setCondition(policyObj, finopsv1alpha1.ConditionCircuitBreakerOpen, true, reason, "enforcement halted: "+message)
+}
+
+// setConflictingCondition lists the policies overlapping this one, recorded
+// in status.conflicts
+func setConflictingCondition(policyObj *finopsv1alpha1.EnforcementPolicy) {
+	if len(policyObj.Status.Conflicts) == 0 {
+		setCondition(policyObj, finopsv1alpha1.ConditionConflicting, false, "NoOverlap", "no other policy matches these workloads")
+		return
+	}
+
+	overlaps := make([]string, 0, len(policyObj.Status.Conflicts))
+	for _, conflict := range policyObj.Status.Conflicts {
+		overlaps = append(overlaps, fmt.Sprintf("%s (%d workloads, %d owned by it)",
+			conflict.Policy, conflict.Workloads, conflict.Yielded))
+	}
+	setCondition(policyObj, finopsv1alpha1.ConditionConflicting, true, "OverlappingPolicies",
+		"overlaps with "+strings.Join(overlaps, ", "))
+}
+
+// setOutcomeConditions records the Ready, Degraded and CostSourceUnavailable
+// conditions and the decision records of a completed reconcile
+func setOutcomeConditions(policyObj *finopsv1alpha1.EnforcementPolicy, outcome *reconcileOutcome) {
+	if outcome.costErrors > 0 {
+		setCondition(policyObj, finopsv1alpha1.ConditionCostSourceUnavailable, true, "CostLookupFailed",
+			fmt.Sprintf("cost data unavailable for %d of %d workloads", outcome.costErrors, outcome.evaluated))
+	} else {
+		setCondition(policyObj, finopsv1alpha1.ConditionCostSourceUnavailable, false, "CostDataAvailable", "cost data available")
+	}
+
+	failures := []string{}
+	if outcome.costErrors > 0 {
+		failures = append(failures, fmt.Sprintf("%d cost lookups", outcome.costErrors))
+	}
+	if outcome.evalErrors > 0 {
+		failures = append(failures, fmt.Sprintf("%d evaluations", outcome.evalErrors))
+	}
+	if outcome.actionErrors > 0 {
+		failures = append(failures, fmt.Sprintf("%d actions", outcome.actionErrors))
+	}
+	if len(failures) > 0 {
+		setCondition(policyObj, finopsv1alpha1.ConditionDegraded, true, "PartialFailure", "failed: "+strings.Join(failures, ", "))
+	} else {
+		setCondition(policyObj, finopsv1alpha1.ConditionDegraded, false, "AsExpected", "no failures")
+	}
+
+	setCondition(policyObj, finopsv1alpha1.ConditionReady, true, "Reconciled",
+		fmt.Sprintf("%d workloads in scope, %d owned by other policies, %d matched",
+			outcome.evaluated, outcome.yielded, outcome.matched))
+
+	policyObj.Status.Decisions = mergeDecisions(outcome.decisions, policyObj.Status.Decisions, maxDecisionRecords)
+}
+
+// mergeDecisions puts the latest decisions first, matched workloads ahead of
+// the rest, keeps older decisions for workloads not decided again and bounds
+// the list to limit entries
+func mergeDecisions(latest, previous []finopsv1alpha1.DecisionRecord, limit int) []finopsv1alpha1.DecisionRecord {
+	sort.SliceStable(latest, func(i, j int) bool {
+		return latest[i].Matched && !latest[j].Matched
+	})
+
+	seen := make(map[string]bool, len(latest))
+	merged := make([]finopsv1alpha1.DecisionRecord, 0, limit)
+	for _, records := range [][]finopsv1alpha1.DecisionRecord{latest, previous} {
+		for _, record := range records {
+			if len(merged) == limit {
+				return merged
+			}
+			if seen[record.Resource] {
+				continue
+			}
+			seen[record.Resource] = true
+			merged = append(merged, record)
+		}
+	}
+
+	return merged
+}
+
+// statusChanged reports whether a policy status differs from the previous one
+// in more than its evaluation and decision timestamps
+func statusChanged(previous, current *finopsv1alpha1.EnforcementPolicyStatus) bool {
+	return !equality.Semantic.DeepEqual(withoutTimestamps(previous), withoutTimestamps(current))
+}
+
+// withoutTimestamps returns a copy of a status with its evaluation and
+// decision timestamps cleared
+func withoutTimestamps(status *finopsv1alpha1.EnforcementPolicyStatus) *finopsv1alpha1.EnforcementPolicyStatus {
+	status = status.DeepCopy()
+	status.LastEvaluationTime = nil
+	for i := range status.Decisions {
+		status.Decisions[i].Timestamp = metav1.Time{}
+	}
+	return status
+}
+
+// pausedSavings sums the estimated monthly savings of paused workloads
+func pausedSavings(paused []*workload.Workload) float64 {
+	total := 0.0
+	for _, w := range paused {
+		savings, err := strconv.ParseFloat(w.GetAnnotations()["finops.io/estimated-monthly-savings"], 64)
+		if err == nil {
+			total += savings
+		}
+	}
+	return total
+}
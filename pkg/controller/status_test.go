@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMergeDecisions(t *testing.T) {
+	record := func(resource string, matched bool) finopsv1alpha1.DecisionRecord {
+		return finopsv1alpha1.DecisionRecord{Resource: resource, Matched: matched}
+	}
+	resources := func(records []finopsv1alpha1.DecisionRecord) []string {
+		names := []string{}
+		for _, r := range records {
+			names = append(names, r.Resource)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name     string
+		latest   []finopsv1alpha1.DecisionRecord
+		previous []finopsv1alpha1.DecisionRecord
+		limit    int
+		want     []string
+	}{
+		{
+			name:   "matched first",
+			latest: []finopsv1alpha1.DecisionRecord{record("a", false), record("b", true), record("c", false)},
+			limit:  10,
+			want:   []string{"b", "a", "c"},
+		},
+		{
+			name:     "previous kept for workloads not decided again",
+			latest:   []finopsv1alpha1.DecisionRecord{record("a", false)},
+			previous: []finopsv1alpha1.DecisionRecord{record("a", true), record("b", false)},
+			limit:    10,
+			want:     []string{"a", "b"},
+		},
+		{
+			name:     "bounded",
+			latest:   []finopsv1alpha1.DecisionRecord{record("a", false), record("b", false)},
+			previous: []finopsv1alpha1.DecisionRecord{record("c", false)},
+			limit:    2,
+			want:     []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resources(mergeDecisions(tt.latest, tt.previous, tt.limit))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeDecisions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetOutcomeConditions(t *testing.T) {
+	policyObj := &finopsv1alpha1.EnforcementPolicy{}
+	setOutcomeConditions(policyObj, &reconcileOutcome{evaluated: 4, matched: 1, costErrors: 2})
+
+	want := map[string]metav1.ConditionStatus{
+		finopsv1alpha1.ConditionReady:                 metav1.ConditionTrue,
+		finopsv1alpha1.ConditionDegraded:              metav1.ConditionTrue,
+		finopsv1alpha1.ConditionCostSourceUnavailable: metav1.ConditionTrue,
+	}
+	for _, condition := range policyObj.Status.Conditions {
+		if status, ok := want[condition.Type]; ok && condition.Status != status {
+			t.Errorf("condition %s = %s, want %s", condition.Type, condition.Status, status)
+		}
+		delete(want, condition.Type)
+	}
+	if len(want) > 0 {
+		t.Errorf("conditions not set: %v", want)
+	}
+}
+
+func TestSetConflictingCondition(t *testing.T) {
+	policyObj := &finopsv1alpha1.EnforcementPolicy{}
+	setConflictingCondition(policyObj)
+	if !meta.IsStatusConditionFalse(policyObj.Status.Conditions, finopsv1alpha1.ConditionConflicting) {
+		t.Errorf("Conflicting = %v, want False without conflicts", policyObj.Status.Conditions)
+	}
+
+	policyObj.Status.Conflicts = []finopsv1alpha1.PolicyConflict{
+		{Policy: "ClusterEnforcementPolicy/defaults", Workloads: 3, Yielded: 0},
+		{Policy: "EnforcementPolicy/dev-a/team-a", Workloads: 1, Yielded: 1},
+	}
+	setConflictingCondition(policyObj)
+	condition := meta.FindStatusCondition(policyObj.Status.Conditions, finopsv1alpha1.ConditionConflicting)
+	want := "overlaps with ClusterEnforcementPolicy/defaults (3 workloads, 0 owned by it), " +
+		"EnforcementPolicy/dev-a/team-a (1 workloads, 1 owned by it)"
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Message != want {
+		t.Errorf("Conflicting = %+v, want True with %q", condition, want)
+	}
+}
+
+func TestPausedSavings(t *testing.T) {
+	paused := func(savings string) *workload.Workload {
+		return workload.FromDeployment(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"finops.io/estimated-monthly-savings": savings},
+			},
+		})
+	}
+
+	got := pausedSavings([]*workload.Workload{paused("12.50"), paused("7.50"), paused("invalid")})
+	if got != 20 {
+		t.Errorf("pausedSavings() = %v, want 20", got)
+	}
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// wakeUp restores workloads paused by a policy whose reactivation schedule or
+// TTL is due. Due workloads are first stamped with a finops.io/reactivate-at
+// slot, StaggerInterval apart, and restored once their slot has passed. It
+// returns how long until the next pending slot or the next time a paused
+// workload becomes due, or zero when there is neither.
+func (r *EnforcementPolicyReconciler) wakeUp(
+	ctx context.Context,
+	policyObj *finopsv1alpha1.EnforcementPolicy,
+	self policy.Candidate,
+	now time.Time,
+) (time.Duration, error) {
+	if policyObj.Spec.Reactivation == nil {
+		return 0, nil
+	}
+	logger := log.FromContext(ctx)
+
+	reactivation, err := policy.ParseReactivation(policyObj)
+	if err != nil {
+		return 0, err
+	}
+
+	owned, err := r.pausedByPolicy(ctx, self)
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return owned[i].Key().String() < owned[j].Key().String()
+	})
+
+	// New slots start after the last slot already handed out
+	next := now
+	for _, w := range owned {
+		if at, ok := policy.ReactivateAt(w); ok && !at.Before(next) {
+			next = at.Add(reactivation.Stagger)
+		}
+	}
+
+	var requeueAfter time.Duration
+	for _, w := range owned {
+		at, scheduled := policy.ReactivateAt(w)
+		if !scheduled {
+			pausedAt, ok := policy.PausedAt(w)
+			if !ok {
+				continue
+			}
+			if !reactivation.Due(pausedAt, now) {
+				if due, ok := reactivation.NextDue(pausedAt, now); ok {
+					requeueAfter = earliest(requeueAfter, due.Sub(now))
+				}
+				continue
+			}
+
+			at, next = next, next.Add(reactivation.Stagger)
+			if at.After(now) {
+				if err := r.Enforcer.ScheduleReactivation(ctx, w, at); err != nil {
+					logger.Error(err, "failed to schedule reactivation",
+						"kind", w.Kind,
+						"workload", w.GetName(),
+						"namespace", w.GetNamespace(),
+					)
+					continue
+				}
+			}
+		}
+
+		if until := at.Sub(now); until > 0 {
+			requeueAfter = earliest(requeueAfter, until)
+			continue
+		}
+
+		if err := r.Enforcer.ScheduledReactivate(ctx, w); err != nil {
+			logger.Error(err, "scheduled reactivation failed",
+				"kind", w.Kind,
+				"workload", w.GetName(),
+				"namespace", w.GetNamespace(),
+			)
+			continue
+		}
+
+		logger.Info("workload reactivated on schedule",
+			"policy", self.Ref(),
+			"kind", w.Kind,
+			"workload", w.GetName(),
+			"namespace", w.GetNamespace(),
+		)
+	}
+
+	return requeueAfter, nil
+}
+
+// earliest returns the shorter of two positive delays, treating zero as unset
+func earliest(current, d time.Duration) time.Duration {
+	if d <= 0 {
+		return current
+	}
+	if current == 0 || d < current {
+		return d
+	}
+	return current
+}
+
+// pausedByPolicy returns the workloads currently paused by a policy
+func (r *EnforcementPolicyReconciler) pausedByPolicy(
+	ctx context.Context,
+	self policy.Candidate,
+) ([]*workload.Workload, error) {
+	paused, err := r.Enforcer.GetPausedWorkloads(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	owned := []*workload.Workload{}
+	for _, w := range paused {
+		if self.MatchesRef(w.GetAnnotations()["finops.io/policy"]) {
+			owned = append(owned, w)
+		}
+	}
+	return owned, nil
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/enforcement"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWakeUpStaggered(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	pausedAt := time.Now().Add(-48 * time.Hour).Format(time.RFC3339)
+	paused := func(name, policyName string) *appsv1.Deployment {
+		replicas := int32(0)
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "staging",
+				Annotations: map[string]string{
+					"finops.io/paused":            "true",
+					"finops.io/paused-at":         pausedAt,
+					"finops.io/policy":            policyName,
+					"finops.io/original-replicas": "3",
+				},
+			},
+			Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		paused("api", "EnforcementPolicy/finops-system/weekend"),
+		paused("web", "EnforcementPolicy/finops-system/weekend"),
+		paused("worker", "weekend"), // paused before refs were recorded
+		paused("other", "another-policy"),
+		paused("same-name", "EnforcementPolicy/team-a/weekend"),
+		paused("cluster", "ClusterEnforcementPolicy/weekend"),
+	).Build()
+
+	policyObj := &finopsv1alpha1.EnforcementPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "weekend", Namespace: "finops-system"},
+		Spec: finopsv1alpha1.EnforcementPolicySpec{
+			Reactivation: &finopsv1alpha1.ReactivationSpec{
+				TTL:             metav1.Duration{Duration: 24 * time.Hour},
+				StaggerInterval: metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}
+	r := &EnforcementPolicyReconciler{Client: c, Enforcer: enforcement.NewExecutor(c)}
+
+	replicas := func(name string) int32 {
+		d := &appsv1.Deployment{}
+		if err := c.Get(context.Background(), client.ObjectKey{Namespace: "staging", Name: name}, d); err != nil {
+			t.Fatalf("Get(%s) error = %v", name, err)
+		}
+		return *d.Spec.Replicas
+	}
+
+	now := time.Now()
+	self := namespacedCandidate(policyObj)
+	requeueAfter, err := r.wakeUp(context.Background(), policyObj, self, now)
+	if err != nil {
+		t.Fatalf("wakeUp() error = %v", err)
+	}
+	if requeueAfter <= 0 || requeueAfter > time.Minute {
+		t.Errorf("requeueAfter = %v, want next slot within a minute", requeueAfter)
+	}
+	if got := replicas("api"); got != 3 {
+		t.Errorf("api replicas = %d, want restored to 3 immediately", got)
+	}
+	if got := replicas("web"); got != 0 {
+		t.Errorf("web replicas = %d, want still 0 until its slot", got)
+	}
+
+	// One stagger interval later the next slot is due, the last is not
+	if _, err := r.wakeUp(context.Background(), policyObj, self, now.Add(time.Minute+time.Second)); err != nil {
+		t.Fatalf("wakeUp() error = %v", err)
+	}
+	if got := replicas("web"); got != 3 {
+		t.Errorf("web replicas = %d, want restored to 3", got)
+	}
+	if got := replicas("worker"); got != 0 {
+		t.Errorf("worker replicas = %d, want still 0 until its slot", got)
+	}
+	for _, name := range []string{"other", "same-name", "cluster"} {
+		if got := replicas(name); got != 0 {
+			t.Errorf("%s replicas = %d, want untouched by another policy", name, got)
+		}
+	}
+}
+
+func TestWakeUpRequeuesAtWakeTime(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	// 2026-01-05 is a Monday; the schedule wakes at 07:00 UTC
+	now := time.Date(2026, 1, 5, 6, 57, 0, 0, time.UTC)
+	paused := func(name string, pausedAt time.Time) *appsv1.Deployment {
+		replicas := int32(0)
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "staging",
+				Annotations: map[string]string{
+					"finops.io/paused":            "true",
+					"finops.io/paused-at":         pausedAt.Format(time.RFC3339),
+					"finops.io/policy":            "EnforcementPolicy/finops-system/weekend",
+					"finops.io/original-replicas": "3",
+				},
+			},
+			Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		pausedAt time.Time
+		want     time.Duration
+	}{
+		{name: "schedule boundary", pausedAt: now.Add(-48 * time.Hour), want: 3 * time.Minute},
+		{name: "ttl expiry", ttl: 2 * time.Hour, pausedAt: now.Add(-119 * time.Minute), want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(paused("api", tt.pausedAt)).Build()
+			policyObj := &finopsv1alpha1.EnforcementPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "weekend", Namespace: "finops-system"},
+				Spec: finopsv1alpha1.EnforcementPolicySpec{
+					Reactivation: &finopsv1alpha1.ReactivationSpec{
+						Schedule: []finopsv1alpha1.ReactivationTime{{Days: []string{"Mon"}, At: "07:00"}},
+						TTL:      metav1.Duration{Duration: tt.ttl},
+					},
+				},
+			}
+			r := &EnforcementPolicyReconciler{Client: c, Enforcer: enforcement.NewExecutor(c)}
+
+			requeueAfter, err := r.wakeUp(context.Background(), policyObj, namespacedCandidate(policyObj), now)
+			if err != nil {
+				t.Fatalf("wakeUp() error = %v", err)
+			}
+			if requeueAfter != tt.want {
+				t.Errorf("requeueAfter = %v, want %v", requeueAfter, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package cost
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/metrics"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+)
+
+// Cache serves cost lookups from a single cluster-wide allocation query per
+// window, so that all workloads and policies evaluated within the TTL share
+// one OpenCost or Kubecost request instead of issuing one per workload. The
+// lock is not held during the request; concurrent lookups of a window being
+// fetched wait for that fetch instead of issuing their own.
+type Cache struct {
+	source AllocationSource
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+	fetches map[time.Duration]*fetch
+	now     func() time.Time
+}
+
+// cacheKey identifies cached costs by namespace and query window. An empty
+// namespace records when the whole cluster was last fetched for the window.
+type cacheKey struct {
+	namespace string
+	window    time.Duration
+}
+
+// cacheEntry holds the costs of one namespace
+type cacheEntry struct {
+	costs   []CostData
+	expires time.Time
+}
+
+// fetch is a cluster-wide query in flight for a window. done is closed once
+// err is set and, on success, the entries are stored.
+type fetch struct {
+	done chan struct{}
+	err  error
+}
+
+// NewCache creates a cost cache in front of an allocation source
+func NewCache(source AllocationSource, ttl time.Duration) *Cache {
+	return &Cache{
+		source:  source,
+		ttl:     ttl,
+		entries: make(map[cacheKey]cacheEntry),
+		fetches: make(map[time.Duration]*fetch),
+		now:     time.Now,
+	}
+}
+
+// GetNamespaceCosts returns cached cost data for a namespace, refreshing the
+// whole cluster with one batched query when the entry is missing or expired
+func (c *Cache) GetNamespaceCosts(ctx context.Context, namespace string, window time.Duration) ([]CostData, error) {
+	key := cacheKey{namespace: namespace, window: window}
+
+	c.mu.Lock()
+	for {
+		// Namespaces without allocations have no entry and are reported as empty
+		if c.now().Before(c.entries[cacheKey{window: window}].expires) {
+			costs := c.entries[key].costs
+			c.mu.Unlock()
+			metrics.OpenCostCacheHits.Inc()
+			return costs, nil
+		}
+
+		inFlight, ok := c.fetches[window]
+		if !ok {
+			break
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-inFlight.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if inFlight.err != nil {
+			return nil, inFlight.err
+		}
+		c.mu.Lock()
+	}
+
+	f := &fetch{done: make(chan struct{})}
+	c.fetches[window] = f
+	c.mu.Unlock()
+	metrics.OpenCostCacheMisses.Inc()
+
+	costs, err := c.source.GetClusterCosts(ctx, window)
+
+	c.mu.Lock()
+	delete(c.fetches, window)
+	f.err = err
+	if err == nil {
+		c.store(window, costs)
+	}
+	namespaceCosts := c.entries[key].costs
+	c.mu.Unlock()
+	close(f.done)
+
+	if err != nil {
+		return nil, err
+	}
+	return namespaceCosts, nil
+}
+
+// GetWorkloadCost retrieves cost data for a specific workload from the cache
+func (c *Cache) GetWorkloadCost(ctx context.Context, key workload.Key, window time.Duration) (*CostData, error) {
+	costs, err := c.GetNamespaceCosts(ctx, key.Namespace, window)
+	if err != nil {
+		return nil, err
+	}
+
+	return findWorkloadCost(costs, key, window)
+}
+
+// HealthCheck verifies the underlying allocation API is accessible
+func (c *Cache) HealthCheck(ctx context.Context) error {
+	return c.source.HealthCheck(ctx)
+}
+
+// store replaces all entries for a window with the result of a cluster-wide query
+func (c *Cache) store(window time.Duration, costs []CostData) {
+	expires := c.now().Add(c.ttl)
+
+	for key := range c.entries {
+		if key.window == window {
+			delete(c.entries, key)
+		}
+	}
+
+	byNamespace := make(map[string][]CostData)
+	for _, cost := range costs {
+		byNamespace[cost.Namespace] = append(byNamespace[cost.Namespace], cost)
+	}
+
+	for namespace, namespaceCosts := range byNamespace {
+		c.entries[cacheKey{namespace: namespace, window: window}] = cacheEntry{
+			costs:   namespaceCosts,
+			expires: expires,
+		}
+	}
+	c.entries[cacheKey{window: window}] = cacheEntry{expires: expires}
+}
@@ -0,0 +1,133 @@
+package cost
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+)
+
+func TestCacheBatchesClusterQuery(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if filter := r.URL.Query().Get("filter"); filter != "" {
+			t.Errorf("unexpected filter %q on batched query", filter)
+		}
+		if got := r.URL.Query().Get("accumulate"); got != "true" {
+			t.Errorf("accumulate = %q, want true", got)
+		}
+		_, _ = w.Write([]byte(`{"code":200,"data":[{
+			"dev-a/deployment/api":{"name":"dev-a/deployment/api",
+				"properties":{"namespace":"dev-a","controller":"api","controllerKind":"deployment"},
+				"start":"2026-01-01T00:00:00Z","end":"2026-01-01T10:00:00Z","totalCost":20},
+			"dev-b/deployment/web":{"name":"dev-b/deployment/web",
+				"properties":{"namespace":"dev-b","controller":"web","controllerKind":"deployment"},
+				"start":"2026-01-01T00:00:00Z","end":"2026-01-01T10:00:00Z","totalCost":5},
+			"__idle__":{"name":"__idle__","properties":{},
+				"start":"2026-01-01T00:00:00Z","end":"2026-01-01T10:00:00Z","totalCost":500}}]}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	cache := NewCache(NewClient(server.URL, 5*time.Second), 5*time.Minute)
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	api := workload.Key{Kind: workload.KindDeployment, Namespace: "dev-a", Name: "api"}
+	web := workload.Key{Kind: workload.KindDeployment, Namespace: "dev-b", Name: "web"}
+
+	for _, key := range []workload.Key{api, web, api} {
+		if _, err := cache.GetWorkloadCost(ctx, key, 48*time.Hour); err != nil {
+			t.Fatalf("GetWorkloadCost(%s) error = %v", key, err)
+		}
+	}
+
+	costs, err := cache.GetNamespaceCosts(ctx, "dev-empty", 48*time.Hour)
+	if err != nil {
+		t.Fatalf("GetNamespaceCosts() error = %v", err)
+	}
+	if len(costs) != 0 {
+		t.Errorf("GetNamespaceCosts() = %v, want no costs", costs)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 batched request", requests)
+	}
+
+	// A different window is cached separately
+	if _, err := cache.GetWorkloadCost(ctx, api, 24*time.Hour); err != nil {
+		t.Fatalf("GetWorkloadCost() error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 after new window", requests)
+	}
+
+	// Entries expire after the TTL
+	now = now.Add(6 * time.Minute)
+	cost, err := cache.GetWorkloadCost(ctx, web, 48*time.Hour)
+	if err != nil {
+		t.Fatalf("GetWorkloadCost() error = %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3 after expiry", requests)
+	}
+	if cost.HourlyCost != 0.5 {
+		t.Errorf("HourlyCost = %v, want 0.5", cost.HourlyCost)
+	}
+}
+
+// blockingSource counts cluster queries and blocks each until release is closed
+type blockingSource struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSource) GetClusterCosts(ctx context.Context, window time.Duration) ([]CostData, error) {
+	s.calls.Add(1)
+	s.started <- struct{}{}
+	<-s.release
+	return []CostData{{Namespace: "dev-a", Controller: "api", ControllerKind: "deployment", HourlyCost: 1}}, nil
+}
+
+func (s *blockingSource) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func TestCacheSharesFetchWithoutHoldingLock(t *testing.T) {
+	source := &blockingSource{started: make(chan struct{}, 2), release: make(chan struct{})}
+	cache := NewCache(source, 5*time.Minute)
+	ctx := context.Background()
+	api := workload.Key{Kind: workload.KindDeployment, Namespace: "dev-a", Name: "api"}
+
+	// A fresh window is served while another window is being fetched
+	cache.store(24*time.Hour, []CostData{{Namespace: "dev-a", Controller: "api", ControllerKind: "deployment"}})
+
+	errs := make(chan error, 3)
+	lookup := func() {
+		_, err := cache.GetWorkloadCost(ctx, api, 48*time.Hour)
+		errs <- err
+	}
+	go lookup()
+	<-source.started
+	go lookup()
+	go lookup()
+
+	if _, err := cache.GetWorkloadCost(ctx, api, 24*time.Hour); err != nil {
+		t.Fatalf("GetWorkloadCost() error = %v", err)
+	}
+
+	close(source.release)
+	for i := 0; i < 3; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("GetWorkloadCost() error = %v", err)
+		}
+	}
+	if calls := source.calls.Load(); calls != 1 {
+		t.Errorf("cluster queries = %d, want 1 shared by concurrent lookups", calls)
+	}
+}
@@ -1,5 +1,4 @@
 package cost
-package cost
 
 import (
 	"context"
@@ -7,168 +6,303 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
 )
 
 // Client provides access to OpenCost API for real-time cost data
 type Client struct {
 	endpoint   string
 	httpClient *http.Client
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-}	return hourlyCost * 730	// Assume 730 hours per month (365 days / 12 months * 24 hours)func EstimateMonthlyCost(hourlyCost float64) float64 {// EstimateMonthlyCost calculates estimated monthly cost from hourly rate}	return fmt.Sprintf("%dh", hours)	}		return fmt.Sprintf("%dd", hours/24)	if hours%24 == 0 {	hours := int(d.Hours())func formatDuration(d time.Duration) string {// formatDuration converts time.Duration to OpenCost window format}	return results	}		})			Timestamp:  time.Now(),			Labels:     allocation.Properties.Labels,			DailyCost:  hourlyCost * 24,			HourlyCost: hourlyCost,			Deployment: allocation.Properties.Deployment,			Namespace:  allocation.Properties.Namespace,		results = append(results, CostData{		}			hourlyCost = allocation.TotalCost / duration		if duration > 0 {		hourlyCost := 0.0		duration := allocation.End.Sub(allocation.Start).Hours()		// Calculate hourly cost from total cost and time window	for _, allocation := range response.Data {	results := make([]CostData, 0, len(response.Data))func parseOpenCostResponse(response OpenCostResponse) []CostData {// parseOpenCostResponse converts OpenCost API response to our CostData format}	Labels     map[string]string `json:"labels"`	Deployment string            `json:"deployment"`	Namespace  string            `json:"namespace"`	Cluster    string            `json:"cluster"`type AllocationProperty struct {// AllocationProperty contains resource properties}	TotalCost  float64            `json:"totalCost"`	End        time.Time          `json:"end"`	Start      time.Time          `json:"start"`	Properties AllocationProperty `json:"properties"`	Name       string             `json:"name"`type OpenCostAllocation struct {// OpenCostAllocation represents a single cost allocation}	Data []OpenCostAllocation `json:"data"`type OpenCostResponse struct {// OpenCostResponse represents the raw response from OpenCost API}	return nil	}		return fmt.Errorf("health check failed: status=%d", resp.StatusCode)	if resp.StatusCode != http.StatusOK {	defer resp.Body.Close()	}		return fmt.Errorf("health check failed: %w", err)	if err != nil {	resp, err := c.httpClient.Do(req)	}		return fmt.Errorf("failed to create health check request: %w", err)	if err != nil {	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint+"/healthz", nil)func (c *Client) HealthCheck(ctx context.Context) error {// HealthCheck verifies OpenCost API is accessible}	return nil, fmt.Errorf("no cost data found for deployment %s/%s", namespace, deployment)	}		}			return &cost, nil		if cost.Deployment == deployment {	for _, cost := range costs {	}		return nil, err	if err != nil {	costs, err := c.GetNamespaceCosts(ctx, namespace, window)func (c *Client) GetDeploymentCost(ctx context.Context, namespace, deployment string, window time.Duration) (*CostData, error) {// GetDeploymentCost retrieves cost data for a specific deployment}	return parseOpenCostResponse(response), nil	}		return nil, fmt.Errorf("failed to decode response: %w", err)	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {	var response OpenCostResponse	}		return nil, fmt.Errorf("opencost api error: status=%d, body=%s", resp.StatusCode, string(body))		body, _ := io.ReadAll(resp.Body)	if resp.StatusCode != http.StatusOK {	defer resp.Body.Close()	}		return nil, fmt.Errorf("failed to fetch cost data: %w", err)	if err != nil {	resp, err := c.httpClient.Do(req)	req.URL.RawQuery = q.Encode()	q.Add("filter", fmt.Sprintf("namespace:%s", namespace))	q.Add("aggregate", "namespace,deployment")	q.Add("window", windowStr)	q := req.URL.Query()	}		return nil, fmt.Errorf("failed to create request: %w", err)	if err != nil {	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)	windowStr := formatDuration(window)	// Window format: "2d" for 2 days, "48h" for 48 hours	// Build query parameters for OpenCost API	url := fmt.Sprintf("%s/allocation", c.endpoint)func (c *Client) GetNamespaceCosts(ctx context.Context, namespace string, window time.Duration) ([]CostData, error) {// GetNamespaceCosts retrieves cost data for all resources in a namespace}	Timestamp  time.Time	Labels     map[string]string	DailyCost  float64	HourlyCost float64	Deployment string	Namespace  stringtype CostData struct {// CostData represents cost information for a resource}	}		},			Timeout: timeout,		httpClient: &http.Client{		endpoint: endpoint,	return &Client{func NewClient(endpoint string, timeout time.Duration) *Client {// NewClient creates a new OpenCost client}
\ No newline at end of file
+}
+
+// NewClient creates a new OpenCost client
+func NewClient(endpoint string, timeout time.Duration) *Client {
+	return &Client{
+		endpoint: endpoint,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// CostData represents cost information for a resource
+type CostData struct {
+	Namespace      string
+	Deployment     string
+	Controller     string
+	ControllerKind string
+	HourlyCost     float64
+	DailyCost      float64
+	TotalCost      float64
+	Labels         map[string]string
+	Timestamp      time.Time
+}
+
+// GetNamespaceCosts retrieves cost data for all resources in a namespace
+func (c *Client) GetNamespaceCosts(ctx context.Context, namespace string, window time.Duration) ([]CostData, error) {
+	return c.getAllocations(ctx, window, fmt.Sprintf("namespace:%s", namespace))
+}
+
+// GetClusterCosts retrieves cost data for all resources in the cluster in a single query
+func (c *Client) GetClusterCosts(ctx context.Context, window time.Duration) ([]CostData, error) {
+	return c.getAllocations(ctx, window, "")
+}
+
+// getAllocations queries the allocation API, optionally restricted by an OpenCost filter
+func (c *Client) getAllocations(ctx context.Context, window time.Duration, filter string) ([]CostData, error) {
+	return fetchAllocations(ctx, c.httpClient, c.endpoint+"/allocation", "opencost", window, filter)
+}
+
+// fetchAllocations queries an OpenCost-compatible allocation endpoint, which
+// OpenCost serves at /allocation and Kubecost at /model/allocation. The label
+// names the API in errors.
+func fetchAllocations(ctx context.Context, httpClient *http.Client, url, label string, window time.Duration, filter string) ([]CostData, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Window format: "2d" for 2 days, "48h" for 48 hours
+	q := req.URL.Query()
+	q.Add("window", formatDuration(window))
+	q.Add("aggregate", "namespace,controllerKind,controller")
+	q.Add("accumulate", "true")
+	if filter != "" {
+		q.Add("filter", filter)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cost data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s api error: status=%d, body=%s", label, resp.StatusCode, string(body))
+	}
+
+	var response OpenCostResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if response.Code != 0 && response.Code != http.StatusOK {
+		return nil, fmt.Errorf("%s api error: code=%d, message=%s", label, response.Code, response.Message)
+	}
+
+	return parseAllocationSets(response.Data), nil
+}
+
+// GetDeploymentCost retrieves cost data for a specific deployment
+func (c *Client) GetDeploymentCost(ctx context.Context, namespace, deployment string, window time.Duration) (*CostData, error) {
+	return c.GetWorkloadCost(ctx, workload.Key{
+		Kind:      workload.KindDeployment,
+		Namespace: namespace,
+		Name:      deployment,
+	}, window)
+}
+
+// GetWorkloadCost retrieves cost data for a specific workload of any supported kind
+func (c *Client) GetWorkloadCost(ctx context.Context, key workload.Key, window time.Duration) (*CostData, error) {
+	costs, err := c.GetNamespaceCosts(ctx, key.Namespace, window)
+	if err != nil {
+		return nil, err
+	}
+
+	return findWorkloadCost(costs, key, window)
+}
+
+// findWorkloadCost picks the cost entry of a workload from a namespace's costs
+func findWorkloadCost(costs []CostData, key workload.Key, window time.Duration) (*CostData, error) {
+	// CronJobs have no pods of their own - sum the Jobs they spawned
+	if key.Kind == workload.KindCronJob {
+		if cost := aggregateCronJobCost(costs, key, window); cost != nil {
+			return cost, nil
+		}
+		return nil, fmt.Errorf("%w for cronjob %s/%s", ErrNoCostData, key.Namespace, key.Name)
+	}
+
+	for _, cost := range costs {
+		if cost.matches(key) {
+			return &cost, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w for %s %s/%s", ErrNoCostData,
+		strings.ToLower(string(key.Kind)), key.Namespace, key.Name)
+}
+
+// matches checks if the cost entry belongs to the given workload
+func (c CostData) matches(key workload.Key) bool {
+	if key.Kind == workload.KindDeployment && c.Deployment == key.Name {
+		return true
+	}
+
+	return strings.EqualFold(c.ControllerKind, string(key.Kind)) && c.Controller == key.Name
+}
+
+// HealthCheck verifies OpenCost API is accessible
+func (c *Client) HealthCheck(ctx context.Context) error {
+	return checkHealth(ctx, c.httpClient, c.endpoint)
+}
+
+// checkHealth probes the /healthz endpoint shared by OpenCost and Kubecost
+func checkHealth(ctx context.Context, httpClient *http.Client, endpoint string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"/healthz", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create health check request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check failed: status=%d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// aggregateCronJobCost sums the cost of all Jobs owned by a CronJob and
+// spreads it over the query window, since Jobs only run part of the time.
+// The CronJob controller names Jobs "<cronjob>-<scheduled unix minutes>",
+// which is how OpenCost reports them under controllerKind "job".
+func aggregateCronJobCost(costs []CostData, key workload.Key, window time.Duration) *CostData {
+	var result *CostData
+	for _, cost := range costs {
+		if cost.ControllerKind != "job" || !isCronJobJob(key.Name, cost.Controller) {
+			continue
+		}
+
+		if result == nil {
+			result = &CostData{
+				Namespace:      cost.Namespace,
+				Controller:     key.Name,
+				ControllerKind: "cronjob",
+				Labels:         cost.Labels,
+				Timestamp:      cost.Timestamp,
+			}
+		}
+		result.TotalCost += cost.TotalCost
+	}
+
+	if result != nil && window.Hours() > 0 {
+		result.HourlyCost = result.TotalCost / window.Hours()
+		result.DailyCost = result.HourlyCost * 24
+	}
+
+	return result
+}
+
+// isCronJobJob checks if a Job name was generated by the named CronJob
+func isCronJobJob(cronJob, job string) bool {
+	suffix, ok := strings.CutPrefix(job, cronJob+"-")
+	if !ok || suffix == "" {
+		return false
+	}
+
+	for _, r := range suffix {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// OpenCostResponse represents the raw response from the OpenCost allocation
+// API, which Kubecost shares. Data holds one allocation set per step, keyed by
+// allocation name; with accumulate=true there is exactly one.
+type OpenCostResponse struct {
+	Code    int                             `json:"code"`
+	Message string                          `json:"message,omitempty"`
+	Data    []map[string]OpenCostAllocation `json:"data"`
+}
+
+// OpenCostAllocation represents a single cost allocation
+type OpenCostAllocation struct {
+	Name       string             `json:"name"`
+	Properties AllocationProperty `json:"properties"`
+	Start      time.Time          `json:"start"`
+	End        time.Time          `json:"end"`
+	TotalCost  float64            `json:"totalCost"`
+}
+
+// AllocationProperty contains resource properties
+type AllocationProperty struct {
+	Cluster        string            `json:"cluster"`
+	Namespace      string            `json:"namespace"`
+	Deployment     string            `json:"deployment"`
+	Controller     string            `json:"controller"`
+	ControllerKind string            `json:"controllerKind"`
+	Labels         map[string]string `json:"labels"`
+}
+
+// parseAllocationSets flattens OpenCost or Kubecost allocation sets into our
+// CostData format
+func parseAllocationSets(sets []map[string]OpenCostAllocation) []CostData {
+	results := []CostData{}
+	for _, set := range sets {
+		for name, allocation := range set {
+			// Skip the synthetic idle and unallocated entries
+			if name == "__idle__" || name == "__unallocated__" {
+				continue
+			}
+			results = append(results, parseAllocation(allocation))
+		}
+	}
+
+	return results
+}
+
+// parseAllocation converts a single allocation to our CostData format
+func parseAllocation(allocation OpenCostAllocation) CostData {
+	// Calculate hourly cost from total cost and time window
+	duration := allocation.End.Sub(allocation.Start).Hours()
+	hourlyCost := 0.0
+	if duration > 0 {
+		hourlyCost = allocation.TotalCost / duration
+	}
+
+	// Controller aggregation reports deployments via controller/controllerKind
+	deployment := allocation.Properties.Deployment
+	if deployment == "" && allocation.Properties.ControllerKind == "deployment" {
+		deployment = allocation.Properties.Controller
+	}
+
+	return CostData{
+		Namespace:      allocation.Properties.Namespace,
+		Deployment:     deployment,
+		Controller:     allocation.Properties.Controller,
+		ControllerKind: allocation.Properties.ControllerKind,
+		HourlyCost:     hourlyCost,
+		DailyCost:      hourlyCost * 24,
+		TotalCost:      allocation.TotalCost,
+		Labels:         allocation.Properties.Labels,
+		Timestamp:      time.Now(),
+	}
+}
+
+// formatDuration converts time.Duration to OpenCost window format
+func formatDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	if hours%24 == 0 {
+		return fmt.Sprintf("%dd", hours/24)
+	}
+
+	return fmt.Sprintf("%dh", hours)
+}
+
+// EstimateMonthlyCost calculates estimated monthly cost from hourly rate
+func EstimateMonthlyCost(hourlyCost float64) float64 {
+	// Assume 730 hours per month (365 days / 12 months * 24 hours)
+	return hourlyCost * 730
+}
@@ -1,10 +1,11 @@
 package cost
-package cost
 
 import (
-	"context"
+	"errors"
 	"testing"
 	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
 )
 
 func TestEstimateMonthlyCost(t *testing.T) {
@@ -97,3 +98,58 @@ func TestClient_HealthCheck(t *testing.T) {
 	// Placeholder for now
 	t.Skip("Requires mock HTTP server")
 }
+
+func TestAggregateCronJobCost(t *testing.T) {
+	costs := []CostData{
+		{Namespace: "dev", Controller: "backup-28493820", ControllerKind: "job", TotalCost: 2.0},
+		{Namespace: "dev", Controller: "backup-28493880", ControllerKind: "job", TotalCost: 4.0},
+		{Namespace: "dev", Controller: "backup-manual", ControllerKind: "job", TotalCost: 100.0},
+		{Namespace: "dev", Controller: "backup-reports-28493820", ControllerKind: "job", TotalCost: 100.0},
+		{Namespace: "dev", Controller: "backup", ControllerKind: "deployment", TotalCost: 100.0},
+	}
+	key := workload.Key{Kind: workload.KindCronJob, Namespace: "dev", Name: "backup"}
+
+	got := aggregateCronJobCost(costs, key, 24*time.Hour)
+	if got == nil {
+		t.Fatal("aggregateCronJobCost() returned nil")
+	}
+
+	if got.TotalCost != 6.0 {
+		t.Errorf("TotalCost = %v, want 6", got.TotalCost)
+	}
+
+	if got.HourlyCost != 0.25 {
+		t.Errorf("HourlyCost = %v, want 0.25", got.HourlyCost)
+	}
+
+	if aggregateCronJobCost(costs, workload.Key{Kind: workload.KindCronJob, Namespace: "dev", Name: "cleanup"}, time.Hour) != nil {
+		t.Error("aggregateCronJobCost() for unknown cronjob should return nil")
+	}
+}
+
+func TestFindWorkloadCost(t *testing.T) {
+	costs := []CostData{
+		{Namespace: "dev", Controller: "api", ControllerKind: "deployment", Deployment: "api", HourlyCost: 1.0},
+		{Namespace: "dev", Controller: "backup-28493820", ControllerKind: "job", TotalCost: 2.0},
+	}
+
+	tests := []struct {
+		name    string
+		key     workload.Key
+		wantErr error
+	}{
+		{name: "deployment", key: workload.Key{Kind: workload.KindDeployment, Namespace: "dev", Name: "api"}},
+		{name: "cronjob", key: workload.Key{Kind: workload.KindCronJob, Namespace: "dev", Name: "backup"}},
+		{name: "missing deployment", key: workload.Key{Kind: workload.KindDeployment, Namespace: "dev", Name: "web"}, wantErr: ErrNoCostData},
+		{name: "missing cronjob", key: workload.Key{Kind: workload.KindCronJob, Namespace: "dev", Name: "cleanup"}, wantErr: ErrNoCostData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := findWorkloadCost(costs, tt.key, 24*time.Hour)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("findWorkloadCost() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
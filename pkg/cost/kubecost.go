@@ -0,0 +1,33 @@
+package cost
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// KubecostClient provides access to the Kubecost allocation API
+type KubecostClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewKubecostClient creates a new Kubecost client
+func NewKubecostClient(endpoint string, timeout time.Duration) *KubecostClient {
+	return &KubecostClient{
+		endpoint: endpoint,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// GetClusterCosts retrieves cost data for all resources in the cluster in a single query
+func (c *KubecostClient) GetClusterCosts(ctx context.Context, window time.Duration) ([]CostData, error) {
+	return fetchAllocations(ctx, c.httpClient, c.endpoint+"/model/allocation", "kubecost", window, "")
+}
+
+// HealthCheck verifies Kubecost API is accessible
+func (c *KubecostClient) HealthCheck(ctx context.Context) error {
+	return checkHealth(ctx, c.httpClient, c.endpoint)
+}
@@ -0,0 +1,60 @@
+package cost
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+)
+
+func TestKubecostClient_GetClusterCosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/model/allocation" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("accumulate"); got != "true" {
+			t.Errorf("accumulate = %q, want true", got)
+		}
+		_, _ = w.Write([]byte(`{"code":200,"data":[{
+			"dev-a/deployment/api":{"name":"dev-a/deployment/api",
+				"properties":{"namespace":"dev-a","controller":"api","controllerKind":"deployment"},
+				"start":"2026-01-01T00:00:00Z","end":"2026-01-03T00:00:00Z","totalCost":96},
+			"__idle__":{"name":"__idle__","properties":{},
+				"start":"2026-01-01T00:00:00Z","end":"2026-01-03T00:00:00Z","totalCost":500}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewKubecostClient(server.URL, 5*time.Second)
+	costs, err := client.GetClusterCosts(context.Background(), 48*time.Hour)
+	if err != nil {
+		t.Fatalf("GetClusterCosts() error = %v", err)
+	}
+	if len(costs) != 1 {
+		t.Fatalf("GetClusterCosts() returned %d entries, want 1", len(costs))
+	}
+
+	cost, err := findWorkloadCost(costs, workload.Key{Kind: workload.KindDeployment, Namespace: "dev-a", Name: "api"}, 48*time.Hour)
+	if err != nil {
+		t.Fatalf("findWorkloadCost() error = %v", err)
+	}
+	if cost.HourlyCost != 2.0 {
+		t.Errorf("HourlyCost = %v, want 2.0", cost.HourlyCost)
+	}
+}
+
+func TestKubecostClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":400,"message":"invalid window"}`))
+	}))
+	defer server.Close()
+
+	client := NewKubecostClient(server.URL, 5*time.Second)
+	_, err := client.GetClusterCosts(context.Background(), time.Hour)
+	if err == nil || !strings.Contains(err.Error(), "kubecost api error") {
+		t.Errorf("GetClusterCosts() error = %v, want a kubecost api error", err)
+	}
+}
@@ -0,0 +1,185 @@
+package cost
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PriceSheet holds per-unit hourly prices
+type PriceSheet struct {
+	// CPU is the price of one vCPU per hour
+	CPU float64
+
+	// Memory is the price of one GiB of memory per hour
+	Memory float64
+}
+
+// Price sheet ConfigMap keys
+const (
+	PriceSheetCPUKey    = "cpu"
+	PriceSheetMemoryKey = "memory"
+)
+
+// PriceSheetProvider computes workload cost offline from pod template
+// resource requests and a price sheet ConfigMap, for clusters without
+// OpenCost or Kubecost
+type PriceSheetProvider struct {
+	client          client.Reader
+	configMapReader client.Reader
+	configMap       client.ObjectKey
+	refresh         time.Duration
+
+	mu       sync.Mutex
+	prices   *PriceSheet
+	loadedAt time.Time
+}
+
+// NewPriceSheetProvider creates a provider reading workloads from c and prices
+// from the given ConfigMap via configMapReader. Prices are reloaded at most
+// once per refresh interval.
+func NewPriceSheetProvider(c, configMapReader client.Reader, configMap client.ObjectKey, refresh time.Duration) *PriceSheetProvider {
+	return &PriceSheetProvider{
+		client:          c,
+		configMapReader: configMapReader,
+		configMap:       configMap,
+		refresh:         refresh,
+	}
+}
+
+// GetWorkloadCost estimates the hourly cost of a workload as its total
+// requested CPU and memory times the configured prices. CronJobs are priced
+// for a single running Job; the window is not used.
+func (p *PriceSheetProvider) GetWorkloadCost(ctx context.Context, key workload.Key, window time.Duration) (*CostData, error) {
+	prices, err := p.loadPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := workload.Get(ctx, p.client, key)
+	if err != nil {
+		return nil, err
+	}
+
+	template, replicas := podTemplate(w)
+	if template == nil {
+		return nil, fmt.Errorf("unsupported workload kind: %s", key.Kind)
+	}
+
+	hourlyCost := prices.HourlyCost(template.Spec) * float64(replicas)
+
+	return &CostData{
+		Namespace:      key.Namespace,
+		Deployment:     deploymentName(key),
+		Controller:     key.Name,
+		ControllerKind: string(key.Kind),
+		HourlyCost:     hourlyCost,
+		DailyCost:      hourlyCost * 24,
+		TotalCost:      hourlyCost * window.Hours(),
+		Labels:         w.GetLabels(),
+		Timestamp:      time.Now(),
+	}, nil
+}
+
+// HealthCheck verifies the price sheet ConfigMap can be read and parsed
+func (p *PriceSheetProvider) HealthCheck(ctx context.Context) error {
+	_, err := p.loadPrices(ctx)
+	return err
+}
+
+// HourlyCost returns the hourly price of one pod with the given spec
+func (s *PriceSheet) HourlyCost(spec corev1.PodSpec) float64 {
+	cpu, memory := 0.0, 0.0
+	for _, container := range spec.Containers {
+		if q, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+			cpu += q.AsApproximateFloat64()
+		}
+		if q, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+			memory += q.AsApproximateFloat64() / (1 << 30)
+		}
+	}
+
+	return cpu*s.CPU + memory*s.Memory
+}
+
+// loadPrices returns cached prices, re-reading the ConfigMap when stale
+func (p *PriceSheetProvider) loadPrices(ctx context.Context) (*PriceSheet, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.prices != nil && time.Since(p.loadedAt) < p.refresh {
+		return p.prices, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := p.configMapReader.Get(ctx, p.configMap, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get price sheet %s: %w", p.configMap, err)
+	}
+
+	prices, err := ParsePriceSheet(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price sheet %s: %w", p.configMap, err)
+	}
+
+	p.prices = prices
+	p.loadedAt = time.Now()
+
+	return prices, nil
+}
+
+// ParsePriceSheet parses price sheet ConfigMap data
+func ParsePriceSheet(data map[string]string) (*PriceSheet, error) {
+	prices := &PriceSheet{}
+	for key, target := range map[string]*float64{
+		PriceSheetCPUKey:    &prices.CPU,
+		PriceSheetMemoryKey: &prices.Memory,
+	} {
+		value, ok := data[key]
+		if !ok {
+			return nil, fmt.Errorf("missing %q price", key)
+		}
+
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil || price < 0 {
+			return nil, fmt.Errorf("invalid %q price: %q", key, value)
+		}
+		*target = price
+	}
+
+	return prices, nil
+}
+
+// podTemplate returns the pod template and number of pods a workload runs
+func podTemplate(w *workload.Workload) (*corev1.PodTemplateSpec, int32) {
+	switch obj := w.Object.(type) {
+	case *appsv1.Deployment:
+		return &obj.Spec.Template, w.Replicas()
+	case *appsv1.StatefulSet:
+		return &obj.Spec.Template, w.Replicas()
+	case *batchv1.CronJob:
+		parallelism := int32(1)
+		if obj.Spec.JobTemplate.Spec.Parallelism != nil {
+			parallelism = *obj.Spec.JobTemplate.Spec.Parallelism
+		}
+		return &obj.Spec.JobTemplate.Spec.Template, parallelism
+	default:
+		return nil, 0
+	}
+}
+
+// deploymentName returns the workload name for Deployments, empty otherwise
+func deploymentName(key workload.Key) string {
+	if key.Kind == workload.KindDeployment {
+		return key.Name
+	}
+
+	return ""
+}
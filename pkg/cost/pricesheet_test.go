@@ -0,0 +1,110 @@
+package cost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParsePriceSheet(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		want    PriceSheet
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: map[string]string{"cpu": "0.031", "memory": "0.004"},
+			want: PriceSheet{CPU: 0.031, Memory: 0.004},
+		},
+		{
+			name:    "missing memory",
+			data:    map[string]string{"cpu": "0.031"},
+			wantErr: true,
+		},
+		{
+			name:    "negative price",
+			data:    map[string]string{"cpu": "-1", "memory": "0.004"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePriceSheet(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePriceSheet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && *got != tt.want {
+				t.Errorf("ParsePriceSheet() = %v, want %v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriceSheetProvider_GetWorkloadCost(t *testing.T) {
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-a"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "app",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("500m"),
+									corev1.ResourceMemory: resource.MustParse("1Gi"),
+								},
+							},
+						},
+						{
+							Name: "sidecar",
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("500m"),
+									corev1.ResourceMemory: resource.MustParse("1Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "finops-price-sheet", Namespace: "finops-system"},
+		Data:       map[string]string{"cpu": "0.04", "memory": "0.005"},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(deployment, configMap).Build()
+	provider := NewPriceSheetProvider(c, c,
+		types.NamespacedName{Namespace: "finops-system", Name: "finops-price-sheet"}, time.Minute)
+
+	cost, err := provider.GetWorkloadCost(context.Background(),
+		workload.Key{Kind: workload.KindDeployment, Namespace: "dev-a", Name: "api"}, 48*time.Hour)
+	if err != nil {
+		t.Fatalf("GetWorkloadCost() error = %v", err)
+	}
+
+	// 3 replicas * (1 vCPU * $0.04 + 2 GiB * $0.005)
+	want := 0.15
+	if diff := cost.HourlyCost - want; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("HourlyCost = %v, want %v", cost.HourlyCost, want)
+	}
+	if cost.Deployment != "api" {
+		t.Errorf("Deployment = %q, want api", cost.Deployment)
+	}
+}
@@ -0,0 +1,45 @@
+package cost
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+)
+
+// ErrNoCostData is returned when the cost backend answered but has no
+// allocation for a workload, e.g. one created after the window started or
+// one with no running pods. It is not a backend failure.
+var ErrNoCostData = errors.New("no cost data found")
+
+// CostProvider supplies cost data for workloads
+type CostProvider interface {
+	// GetWorkloadCost returns the cost of a workload averaged over the window
+	GetWorkloadCost(ctx context.Context, key workload.Key, window time.Duration) (*CostData, error)
+
+	// HealthCheck verifies the cost backend is reachable
+	HealthCheck(ctx context.Context) error
+}
+
+// AllocationSource is a cost backend that can report allocations for the
+// whole cluster in one query, such as OpenCost or Kubecost
+type AllocationSource interface {
+	GetClusterCosts(ctx context.Context, window time.Duration) ([]CostData, error)
+	HealthCheck(ctx context.Context) error
+}
+
+// Provider names accepted by the --cost-provider flag
+const (
+	ProviderOpenCost   = "opencost"
+	ProviderKubecost   = "kubecost"
+	ProviderPriceSheet = "pricesheet"
+)
+
+var (
+	_ CostProvider     = &Client{}
+	_ CostProvider     = &Cache{}
+	_ CostProvider     = &PriceSheetProvider{}
+	_ AllocationSource = &Client{}
+	_ AllocationSource = &KubecostClient{}
+)
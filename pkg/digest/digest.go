@@ -0,0 +1,322 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// stateKey is the ConfigMap data key holding the serialized State
+const stateKey = "state.json"
+
+// Counts aggregates enforcement activity
+type Counts struct {
+	Pauses         int `json:"pauses,omitempty"`
+	Reactivations  int `json:"reactivations,omitempty"`
+	FalsePositives int `json:"falsePositives,omitempty"`
+
+	// EstimatedSavings is the net monthly savings: paused minus reactivated
+	EstimatedSavings float64 `json:"estimatedSavings,omitempty"`
+}
+
+// IsZero reports whether no activity was recorded
+func (c Counts) IsZero() bool {
+	return c == Counts{}
+}
+
+// add accumulates other into c
+func (c *Counts) add(other Counts) {
+	c.Pauses += other.Pauses
+	c.Reactivations += other.Reactivations
+	c.FalsePositives += other.FalsePositives
+	c.EstimatedSavings += other.EstimatedSavings
+}
+
+// sub removes other from c
+func (c *Counts) sub(other Counts) {
+	c.Pauses -= other.Pauses
+	c.Reactivations -= other.Reactivations
+	c.FalsePositives -= other.FalsePositives
+	c.EstimatedSavings -= other.EstimatedSavings
+}
+
+// PolicyState is the activity of one policy in the current digest period
+type PolicyState struct {
+	PeriodStart time.Time         `json:"periodStart"`
+	Namespaces  map[string]Counts `json:"namespaces,omitempty"`
+}
+
+// State is the persisted digest state of all policies
+type State struct {
+	Policies map[string]*PolicyState `json:"policies,omitempty"`
+}
+
+// Digest summarizes one policy's activity over a period
+type Digest struct {
+	Policy      string                         `json:"policy"`
+	Frequency   finopsv1alpha1.DigestFrequency `json:"frequency"`
+	PeriodStart time.Time                      `json:"periodStart"`
+	PeriodEnd   time.Time                      `json:"periodEnd"`
+	Namespaces  []NamespaceCounts              `json:"namespaces"`
+	Totals      Counts                         `json:"totals"`
+}
+
+// NamespaceCounts is the activity in one namespace
+type NamespaceCounts struct {
+	Namespace string `json:"namespace"`
+	Counts
+}
+
+// ForNamespaces returns the part of a digest covering the given namespaces,
+// with totals over those namespaces only
+func (d *Digest) ForNamespaces(namespaces []string) *Digest {
+	keep := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		keep[namespace] = true
+	}
+
+	part := *d
+	part.Namespaces = nil
+	part.Totals = Counts{}
+	for _, ns := range d.Namespaces {
+		if keep[ns.Namespace] {
+			part.Namespaces = append(part.Namespaces, ns)
+			part.Totals.add(ns.Counts)
+		}
+	}
+
+	return &part
+}
+
+// Period returns the length of a digest period
+func Period(frequency finopsv1alpha1.DigestFrequency) time.Duration {
+	switch frequency {
+	case finopsv1alpha1.DigestDaily:
+		return 24 * time.Hour
+	case finopsv1alpha1.DigestWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// Store persists digest state in a ConfigMap so it survives controller restarts
+type Store struct {
+	client    client.Client
+	reader    client.Reader
+	configMap client.ObjectKey
+
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// NewStore creates a store persisting state in the given ConfigMap, written
+// through c and read through reader
+func NewStore(c client.Client, reader client.Reader, configMap client.ObjectKey) *Store {
+	return &Store{
+		client:    c,
+		reader:    reader,
+		configMap: configMap,
+		now:       time.Now,
+	}
+}
+
+// RecordPause counts a paused workload. A nil store records nothing.
+func (s *Store) RecordPause(ctx context.Context, policy, namespace string, savings float64) error {
+	return s.record(ctx, policy, namespace, Counts{Pauses: 1, EstimatedSavings: savings})
+}
+
+// RecordReactivation counts a reactivated workload. A nil store records nothing.
+func (s *Store) RecordReactivation(ctx context.Context, policy, namespace string, savings float64) error {
+	return s.record(ctx, policy, namespace, Counts{Reactivations: 1, EstimatedSavings: -savings})
+}
+
+// RecordFalsePositive counts a reactivation soon after a pause. A nil store records nothing.
+func (s *Store) RecordFalsePositive(ctx context.Context, policy, namespace string) error {
+	return s.record(ctx, policy, namespace, Counts{FalsePositives: 1})
+}
+
+// record adds counts to a policy's current period
+func (s *Store) record(ctx context.Context, policy, namespace string, counts Counts) error {
+	if s == nil || policy == "" {
+		return nil
+	}
+
+	return s.update(ctx, func(state *State) {
+		ps := s.policyState(state, policy)
+		nsCounts := ps.Namespaces[namespace]
+		nsCounts.add(counts)
+		ps.Namespaces[namespace] = nsCounts
+	})
+}
+
+// Due returns the digest of a policy whose period has ended. A policy seen
+// for the first time starts its period now and is not due.
+func (s *Store) Due(ctx context.Context, policy string, frequency finopsv1alpha1.DigestFrequency) (*Digest, error) {
+	var digest *Digest
+	now := s.now()
+
+	err := s.update(ctx, func(state *State) {
+		digest = nil
+		ps := s.policyState(state, policy)
+		if now.Sub(ps.PeriodStart) < Period(frequency) {
+			return
+		}
+
+		digest = &Digest{
+			Policy:      policy,
+			Frequency:   frequency,
+			PeriodStart: ps.PeriodStart,
+			PeriodEnd:   now,
+		}
+		for namespace, counts := range ps.Namespaces {
+			if counts.IsZero() {
+				continue
+			}
+			digest.Namespaces = append(digest.Namespaces, NamespaceCounts{Namespace: namespace, Counts: counts})
+			digest.Totals.add(counts)
+		}
+		sort.Slice(digest.Namespaces, func(i, j int) bool {
+			return digest.Namespaces[i].Namespace < digest.Namespaces[j].Namespace
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return digest, nil
+}
+
+// Complete starts the next period of a policy after its digest was sent,
+// keeping activity recorded while the digest was being delivered
+func (s *Store) Complete(ctx context.Context, digest *Digest) error {
+	return s.update(ctx, func(state *State) {
+		ps := s.policyState(state, digest.Policy)
+		ps.PeriodStart = digest.PeriodEnd
+		for _, sent := range digest.Namespaces {
+			counts := ps.Namespaces[sent.Namespace]
+			counts.sub(sent.Counts)
+			if counts.IsZero() {
+				delete(ps.Namespaces, sent.Namespace)
+				continue
+			}
+			ps.Namespaces[sent.Namespace] = counts
+		}
+	})
+}
+
+// Prune drops the state of policies not in keep
+func (s *Store) Prune(ctx context.Context, keep map[string]bool) error {
+	return s.update(ctx, func(state *State) {
+		for policy := range state.Policies {
+			if !keep[policy] {
+				delete(state.Policies, policy)
+			}
+		}
+	})
+}
+
+// RenamePolicies moves the state recorded under old policy keys to new ones,
+// merging it with state already recorded under the new key
+func (s *Store) RenamePolicies(ctx context.Context, renames map[string]string) error {
+	if len(renames) == 0 {
+		return nil
+	}
+
+	return s.update(ctx, func(state *State) {
+		for from, to := range renames {
+			old, ok := state.Policies[from]
+			if !ok || from == to {
+				continue
+			}
+			delete(state.Policies, from)
+
+			ps := s.policyState(state, to)
+			if old.PeriodStart.Before(ps.PeriodStart) {
+				ps.PeriodStart = old.PeriodStart
+			}
+			for namespace, counts := range old.Namespaces {
+				nsCounts := ps.Namespaces[namespace]
+				nsCounts.add(counts)
+				ps.Namespaces[namespace] = nsCounts
+			}
+		}
+	})
+}
+
+// policyState returns the state of a policy, creating it when missing
+func (s *Store) policyState(state *State, policy string) *PolicyState {
+	if state.Policies == nil {
+		state.Policies = make(map[string]*PolicyState)
+	}
+
+	ps := state.Policies[policy]
+	if ps == nil {
+		ps = &PolicyState{PeriodStart: s.now()}
+		state.Policies[policy] = ps
+	}
+	if ps.Namespaces == nil {
+		ps.Namespaces = make(map[string]Counts)
+	}
+
+	return ps
+}
+
+// update applies fn to the stored state, creating the ConfigMap on first use
+func (s *Store) update(ctx context.Context, fn func(*State)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Another replica may create or update the ConfigMap concurrently
+	retriable := func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}
+
+	return retry.OnError(retry.DefaultRetry, retriable, func() error {
+		configMap := &corev1.ConfigMap{}
+		err := s.reader.Get(ctx, s.configMap, configMap)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get digest state %s: %w", s.configMap, err)
+		}
+		exists := err == nil
+
+		state := &State{}
+		if raw := configMap.Data[stateKey]; raw != "" {
+			if err := json.Unmarshal([]byte(raw), state); err != nil {
+				return fmt.Errorf("invalid digest state %s: %w", s.configMap, err)
+			}
+		}
+
+		fn(state)
+
+		raw, err := json.Marshal(state)
+		if err != nil {
+			return fmt.Errorf("failed to marshal digest state: %w", err)
+		}
+		if exists && configMap.Data[stateKey] == string(raw) {
+			return nil
+		}
+
+		if !exists {
+			configMap.Namespace = s.configMap.Namespace
+			configMap.Name = s.configMap.Name
+			configMap.Data = map[string]string{stateKey: string(raw)}
+			return s.client.Create(ctx, configMap)
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		configMap.Data[stateKey] = string(raw)
+		return s.client.Update(ctx, configMap)
+	})
+}
@@ -0,0 +1,97 @@
+package digest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	key := client.ObjectKey{Namespace: "finops-system", Name: "finops-digest"}
+	ctx := context.Background()
+
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	newStore := func() *Store {
+		s := NewStore(c, c, key)
+		s.now = func() time.Time { return now }
+		return s
+	}
+
+	store := newStore()
+	if err := store.RecordPause(ctx, "dev-idle", "dev-a", 100); err != nil {
+		t.Fatalf("RecordPause() error = %v", err)
+	}
+	_ = store.RecordPause(ctx, "dev-idle", "dev-b", 50)
+	_ = store.RecordReactivation(ctx, "dev-idle", "dev-a", 100)
+	_ = store.RecordFalsePositive(ctx, "dev-idle", "dev-a")
+
+	// Period has not ended yet
+	if d, err := store.Due(ctx, "dev-idle", finopsv1alpha1.DigestDaily); err != nil || d != nil {
+		t.Fatalf("Due() = %v, %v, want nil before the period ends", d, err)
+	}
+
+	// State survives a restart: a new store reads the same ConfigMap
+	now = now.Add(25 * time.Hour)
+	store = newStore()
+	d, err := store.Due(ctx, "dev-idle", finopsv1alpha1.DigestDaily)
+	if err != nil || d == nil {
+		t.Fatalf("Due() = %v, %v, want a digest", d, err)
+	}
+
+	want := Counts{Pauses: 2, Reactivations: 1, FalsePositives: 1, EstimatedSavings: 50}
+	if d.Totals != want {
+		t.Errorf("Totals = %+v, want %+v", d.Totals, want)
+	}
+	if len(d.Namespaces) != 2 || d.Namespaces[0].Namespace != "dev-a" || d.Namespaces[1].Namespace != "dev-b" {
+		t.Errorf("Namespaces = %+v, want dev-a and dev-b", d.Namespaces)
+	}
+
+	// Activity recorded while the digest is delivered is kept for the next period
+	_ = store.RecordPause(ctx, "dev-idle", "dev-b", 25)
+	if err := store.Complete(ctx, d); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	now = now.Add(25 * time.Hour)
+	d, err = store.Due(ctx, "dev-idle", finopsv1alpha1.DigestDaily)
+	if err != nil || d == nil {
+		t.Fatalf("Due() = %v, %v, want a digest", d, err)
+	}
+	want = Counts{Pauses: 1, EstimatedSavings: 25}
+	if d.Totals != want {
+		t.Errorf("Totals after complete = %+v, want %+v", d.Totals, want)
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	tests := []struct {
+		frequency finopsv1alpha1.DigestFrequency
+		want      time.Duration
+	}{
+		{frequency: finopsv1alpha1.DigestDaily, want: 24 * time.Hour},
+		{frequency: finopsv1alpha1.DigestWeekly, want: 7 * 24 * time.Hour},
+		{frequency: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := Period(tt.frequency); got != tt.want {
+			t.Errorf("Period(%q) = %v, want %v", tt.frequency, got, tt.want)
+		}
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var store *Store
+	if err := store.RecordPause(context.Background(), "dev-idle", "dev-a", 10); err != nil {
+		t.Errorf("RecordPause() on nil store error = %v", err)
+	}
+}
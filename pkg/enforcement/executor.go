@@ -1,162 +1,506 @@
 package enforcement
-package enforcement
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/digest"
+	"github.com/yourusername/finops-enforcer/pkg/guardrail"
+	"github.com/yourusername/finops-enforcer/pkg/metrics"
 	"github.com/yourusername/finops-enforcer/pkg/policy"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
 	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-}	return paused, nil	}		}			paused = append(paused, deployment)		if deployment.Annotations["finops.io/paused"] == "true" {	for _, deployment := range deploymentList.Items {	paused := []appsv1.Deployment{}	}		return nil, fmt.Errorf("failed to list deployments: %w", err)	if err := e.client.List(ctx, deploymentList, listOpts...); err != nil {	}		listOpts = append(listOpts, client.InNamespace(namespace))	if namespace != "" {	listOpts := []client.ListOption{}		deploymentList := &appsv1.DeploymentList{}func (e *Executor) GetPausedDeployments(ctx context.Context, namespace string) ([]appsv1.Deployment, error) {// GetPausedDeployments returns all deployments paused by FinOps Enforcer}	return nil	)		"replicas", originalReplicas,		"namespace", namespace,		"deployment", name,	logger.Info("successfully reactivated deployment",	}		return fmt.Errorf("failed to reactivate deployment: %w", err)	if err := e.client.Update(ctx, deployment); err != nil {	// Update deployment	deployment.Annotations["finops.io/last-reactivation"] = time.Now().Format(time.RFC3339)	// Keep original-replicas for historical tracking		delete(deployment.Annotations, "finops.io/paused-at")	delete(deployment.Annotations, "finops.io/paused")	// Clean up pause annotations	deployment.Spec.Replicas = &replicas	replicas := int32(originalReplicas)	// Restore replicas	}		return fmt.Errorf("invalid original-replicas value: %w", err)	if err != nil {	originalReplicas, err := strconv.ParseInt(originalReplicasStr, 10, 32)	}		return fmt.Errorf("missing original-replicas annotation")	if originalReplicasStr == "" {	originalReplicasStr := deployment.Annotations["finops.io/original-replicas"]	// Read original replica count	}		return fmt.Errorf("deployment %s/%s is not paused", namespace, name)	if deployment.Annotations["finops.io/paused"] != "true" {	// Verify it's actually paused	}		return fmt.Errorf("failed to get deployment: %w", err)	}, deployment); err != nil {		Name:      name,		Namespace: namespace,	if err := e.client.Get(ctx, client.ObjectKey{	deployment := &appsv1.Deployment{}	logger := log.FromContext(ctx)func (e *Executor) ReactivateDeployment(ctx context.Context, namespace, name string) error {// ReactivateDeployment restores a paused deployment to its original state}	return nil	)		"estimated_monthly_savings", action.EstimatedMonthlySavings,		"original_replicas", action.OriginalReplicas,		"namespace", deployment.Namespace,		"deployment", deployment.Name,	logger.Info("successfully paused deployment",	}		return fmt.Errorf("failed to scale deployment to zero: %w", err)	if err := e.client.Update(ctx, deployment); err != nil {	// Update deployment	deployment.Spec.Replicas = &replicas	replicas := int32(0)	// Scale to zero	deployment.Annotations["finops.io/estimated-monthly-savings"] = fmt.Sprintf("%.2f", action.EstimatedMonthlySavings)	deployment.Annotations["finops.io/reason"] = action.Reason	deployment.Annotations["finops.io/policy"] = action.Policy	deployment.Annotations["finops.io/original-replicas"] = strconv.Itoa(int(action.OriginalReplicas))	deployment.Annotations["finops.io/paused-at"] = time.Now().Format(time.RFC3339)	deployment.Annotations["finops.io/paused"] = "true"	}		deployment.Annotations = make(map[string]string)	if deployment.Annotations == nil {	// Store original replica count	deployment := action.Deployment	logger := log.FromContext(ctx)func (e *Executor) scaleToZero(ctx context.Context, action *policy.EnforcementAction) error {// scaleToZero scales a deployment to zero replicas with proper tracking}	}		return fmt.Errorf("unsupported action type: %s", action.Type)	default:		return e.scaleToZero(ctx, action)	case "scaleToZero":	switch action.Type {	}		return nil		)			"reason", action.Reason,			"namespace", action.Deployment.Namespace,			"deployment", action.Deployment.Name,			"action", action.Type,		logger.Info("DRY-RUN: would execute action",	if action.DryRun {	logger := log.FromContext(ctx)func (e *Executor) ExecuteAction(ctx context.Context, action *policy.EnforcementAction) error {// ExecuteAction performs the enforcement action with proper annotation and tracking}	}		client: client,	return &Executor{func NewExecutor(client client.Client) *Executor {// NewExecutor creates a new enforcement executor}	client client.Clienttype Executor struct {// Executor handles enforcement action execution with safety guardrails)	"sigs.k8s.io/controller-runtime/pkg/log"
\ No newline at end of file
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ErrReactivationNotAllowed is returned when the owning policy sets reactivationAllowed=false
+var ErrReactivationNotAllowed = errors.New("reactivation is not allowed by the owning policy")
+
+// Executor handles enforcement action execution with safety guardrails
+type Executor struct {
+	client client.Client
+	digest *digest.Store
+	guard  *guardrail.Guard
+}
+
+// Option configures an Executor
+type Option func(*Executor)
+
+// WithDigest records reactivations in the notification digest
+func WithDigest(store *digest.Store) Option {
+	return func(e *Executor) {
+		e.digest = store
+	}
+}
+
+// WithGuard counts user reactivations toward the circuit breaker
+func WithGuard(guard *guardrail.Guard) Option {
+	return func(e *Executor) {
+		e.guard = guard
+	}
+}
+
+// NewExecutor creates a new enforcement executor
+func NewExecutor(client client.Client, opts ...Option) *Executor {
+	e := &Executor{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// ExecuteAction performs the enforcement action with proper annotation and tracking
+func (e *Executor) ExecuteAction(ctx context.Context, action *policy.EnforcementAction) error {
+	logger := log.FromContext(ctx)
+
+	// Warnings are stamped in dry-run too, so each is sent once per period
+	if action.Warning {
+		return e.schedulePause(ctx, action)
+	}
+
+	if action.DryRun {
+		logger.Info("DRY-RUN: would execute action",
+			"action", action.Type,
+			"kind", action.Workload.Kind,
+			"workload", action.Workload.GetName(),
+			"namespace", action.Workload.GetNamespace(),
+			"reason", action.Reason,
+		)
+		return nil
+	}
+
+	switch action.Type {
+	case finopsv1alpha1.ActionTypeScaleToZero:
+		return e.scaleToZero(ctx, action)
+	case finopsv1alpha1.ActionTypeSuspend:
+		return e.suspend(ctx, action)
+	default:
+		return fmt.Errorf("unsupported action type: %s", action.Type)
+	}
+}
+
+// scaleToZero scales a workload to zero replicas with proper tracking
+func (e *Executor) scaleToZero(ctx context.Context, action *policy.EnforcementAction) error {
+	logger := log.FromContext(ctx)
+	w := action.Workload
+
+	if !w.Kind.Scalable() {
+		return fmt.Errorf("scaleToZero is not supported for %s", w.Kind)
+	}
+
+	// Store original replica count
+	annotations := pauseAnnotations(action)
+	annotations["finops.io/original-replicas"] = strconv.Itoa(int(action.OriginalReplicas))
+	w.SetAnnotations(annotations)
+
+	// Scale to zero
+	w.SetReplicas(0)
+
+	// Update workload
+	if err := e.client.Update(ctx, w.Object); err != nil {
+		return fmt.Errorf("failed to scale %s to zero: %w", strings.ToLower(string(w.Kind)), err)
+	}
+
+	logger.Info("successfully paused workload",
+		"kind", w.Kind,
+		"workload", w.GetName(),
+		"namespace", w.GetNamespace(),
+		"original_replicas", action.OriginalReplicas,
+		"estimated_monthly_savings", action.EstimatedMonthlySavings,
+	)
+	return nil
+}
+
+// schedulePause stamps finops.io/pause-scheduled-at on a warned workload.
+// Dry-run warnings are marked with finops.io/pause-scheduled-dry-run so that
+// an enforcing policy warns again before pausing.
+func (e *Executor) schedulePause(ctx context.Context, action *policy.EnforcementAction) error {
+	logger := log.FromContext(ctx)
+	w := action.Workload
+
+	annotations := w.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations["finops.io/pause-scheduled-at"] = action.ScheduledAt.Format(time.RFC3339)
+	if action.DryRun {
+		annotations["finops.io/pause-scheduled-dry-run"] = "true"
+	} else {
+		delete(annotations, "finops.io/pause-scheduled-dry-run")
+	}
+	w.SetAnnotations(annotations)
+
+	if err := e.client.Update(ctx, w.Object); err != nil {
+		return fmt.Errorf("failed to schedule pause of %s: %w", strings.ToLower(string(w.Kind)), err)
+	}
+
+	logger.Info("scheduled pause of idle workload",
+		"kind", w.Kind,
+		"workload", w.GetName(),
+		"namespace", w.GetNamespace(),
+		"scheduled_at", action.ScheduledAt,
+		"dry_run", action.DryRun,
+	)
+	return nil
+}
+
+// CancelScheduledPause removes finops.io/pause-scheduled-at from a workload
+// that became active or was snoozed during its warning period
+func (e *Executor) CancelScheduledPause(ctx context.Context, w *workload.Workload) error {
+	annotations := w.GetAnnotations()
+	if _, ok := annotations["finops.io/pause-scheduled-at"]; !ok {
+		return nil
+	}
+	delete(annotations, "finops.io/pause-scheduled-at")
+	delete(annotations, "finops.io/pause-scheduled-dry-run")
+	w.SetAnnotations(annotations)
+
+	if err := e.client.Update(ctx, w.Object); err != nil {
+		return fmt.Errorf("failed to cancel scheduled pause of %s: %w", strings.ToLower(string(w.Kind)), err)
+	}
+
+	return nil
+}
+
+// suspend sets spec.suspend=true on a CronJob with proper tracking
+func (e *Executor) suspend(ctx context.Context, action *policy.EnforcementAction) error {
+	logger := log.FromContext(ctx)
+	w := action.Workload
+
+	if !w.Kind.Suspendable() {
+		return fmt.Errorf("suspend is not supported for %s", w.Kind)
+	}
+
+	// Store prior suspend state
+	annotations := pauseAnnotations(action)
+	annotations["finops.io/original-suspend"] = strconv.FormatBool(w.Suspended())
+	w.SetAnnotations(annotations)
+
+	// Suspend
+	w.SetSuspended(true)
+
+	// Update workload
+	if err := e.client.Update(ctx, w.Object); err != nil {
+		return fmt.Errorf("failed to suspend %s: %w", strings.ToLower(string(w.Kind)), err)
+	}
+
+	logger.Info("successfully suspended workload",
+		"kind", w.Kind,
+		"workload", w.GetName(),
+		"namespace", w.GetNamespace(),
+		"estimated_monthly_savings", action.EstimatedMonthlySavings,
+	)
+	return nil
+}
+
+// pauseAnnotations returns the workload annotations with the common pause bookkeeping applied
+func pauseAnnotations(action *policy.EnforcementAction) map[string]string {
+	annotations := action.Workload.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	delete(annotations, "finops.io/pause-scheduled-at")
+	delete(annotations, "finops.io/pause-scheduled-dry-run")
+	delete(annotations, "finops.io/reactivate-at")
+	annotations["finops.io/paused"] = "true"
+	annotations["finops.io/paused-at"] = time.Now().Format(time.RFC3339)
+	annotations["finops.io/policy"] = action.Policy
+	annotations["finops.io/reason"] = action.Reason
+	annotations["finops.io/estimated-monthly-savings"] = fmt.Sprintf("%.2f", action.EstimatedMonthlySavings)
+	return annotations
+}
+
+// ReactivateDeployment restores a paused deployment to its original state
+func (e *Executor) ReactivateDeployment(ctx context.Context, namespace, name string) error {
+	return e.ReactivateWorkload(ctx, workload.Key{
+		Kind:      workload.KindDeployment,
+		Namespace: namespace,
+		Name:      name,
+	})
+}
+
+// ReactivateWorkload restores a paused workload to its original state
+func (e *Executor) ReactivateWorkload(ctx context.Context, key workload.Key) error {
+	w, err := workload.Get(ctx, e.client, key)
+	if err != nil {
+		return err
+	}
+
+	return e.reactivate(ctx, w)
+}
+
+// Reactivate restores a paused workload on behalf of a user. It refuses when
+// the owning policy disallows reactivation and records the reactivation
+// metric with the given source. The returned workload reflects the restored state.
+func (e *Executor) Reactivate(ctx context.Context, key workload.Key, source string) (*workload.Workload, error) {
+	w, err := workload.Get(ctx, e.client, key)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := e.ReactivationAllowed(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrReactivationNotAllowed
+	}
+
+	if err := e.reactivateAndRecord(ctx, w, source); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// ScheduleReactivation stamps finops.io/reactivate-at on a paused workload
+// whose scheduled wake-up is due, reserving its staggered restore slot
+func (e *Executor) ScheduleReactivation(ctx context.Context, w *workload.Workload, at time.Time) error {
+	annotations := w.GetAnnotations()
+	annotations["finops.io/reactivate-at"] = at.Format(time.RFC3339)
+	w.SetAnnotations(annotations)
+
+	if err := e.client.Update(ctx, w.Object); err != nil {
+		return fmt.Errorf("failed to schedule reactivation of %s: %w", strings.ToLower(string(w.Kind)), err)
+	}
+
+	return nil
+}
+
+// ScheduledReactivate restores a workload on behalf of its policy's
+// reactivation schedule, regardless of reactivationAllowed
+func (e *Executor) ScheduledReactivate(ctx context.Context, w *workload.Workload) error {
+	return e.reactivateAndRecord(ctx, w, metrics.ReactivationSourceSchedule)
+}
+
+// reactivateAndRecord restores a workload and records the reactivation
+// metric and digest entry with the given source
+func (e *Executor) reactivateAndRecord(ctx context.Context, w *workload.Workload, source string) error {
+	// Read bookkeeping before the pause annotations are cleared
+	policyName := w.GetAnnotations()["finops.io/policy"]
+	savings, _ := strconv.ParseFloat(w.GetAnnotations()["finops.io/estimated-monthly-savings"], 64)
+
+	if err := e.reactivate(ctx, w); err != nil {
+		return err
+	}
+
+	metrics.RecordReactivation(w.GetNamespace(), policyName, source, savings)
+	if source != metrics.ReactivationSourceSchedule {
+		e.guard.RecordReactivation()
+	}
+	if err := e.digest.RecordReactivation(ctx, policyName, w.GetNamespace(), savings); err != nil {
+		log.FromContext(ctx).Error(err, "failed to record reactivation in digest", "policy", policyName)
+	}
+	return nil
+}
+
+// ReactivationAllowed checks the reactivationAllowed setting of the policy that
+// paused a workload. Workloads whose policy no longer exists may be reactivated.
+func (e *Executor) ReactivationAllowed(ctx context.Context, w *workload.Workload) (bool, error) {
+	ref := w.GetAnnotations()["finops.io/policy"]
+	if ref == "" {
+		return true, nil
+	}
+
+	owner, ok := policy.ParseRef(ref)
+	if !ok {
+		return e.legacyReactivationAllowed(ctx, ref)
+	}
+
+	var spec *finopsv1alpha1.EnforcementPolicySpec
+	if owner.Kind == policy.KindClusterEnforcementPolicy {
+		p := &finopsv1alpha1.ClusterEnforcementPolicy{}
+		if err := e.client.Get(ctx, client.ObjectKey{Name: owner.Name}, p); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, fmt.Errorf("failed to get policy %s: %w", ref, err)
+		}
+		spec = &p.Spec
+	} else {
+		p := &finopsv1alpha1.EnforcementPolicy{}
+		if err := e.client.Get(ctx, client.ObjectKey{Namespace: owner.Namespace, Name: owner.Name}, p); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, fmt.Errorf("failed to get policy %s: %w", ref, err)
+		}
+		spec = &p.Spec
+	}
+
+	return spec.Actions.ReactivationAllowed, nil
+}
+
+// legacyReactivationAllowed checks a workload paused before the policy Ref
+// was recorded, which names its policy by name only. Reactivation is refused
+// if any EnforcementPolicy or ClusterEnforcementPolicy of that name disallows it.
+func (e *Executor) legacyReactivationAllowed(ctx context.Context, name string) (bool, error) {
+	policies := &finopsv1alpha1.EnforcementPolicyList{}
+	if err := e.client.List(ctx, policies); err != nil {
+		return false, fmt.Errorf("failed to list policies: %w", err)
+	}
+
+	for _, p := range policies.Items {
+		if p.Name == name && !p.Spec.Actions.ReactivationAllowed {
+			return false, nil
+		}
+	}
+
+	clusterPolicies := &finopsv1alpha1.ClusterEnforcementPolicyList{}
+	if err := e.client.List(ctx, clusterPolicies); err != nil {
+		return false, fmt.Errorf("failed to list cluster policies: %w", err)
+	}
+
+	for _, p := range clusterPolicies.Items {
+		if p.Name == name && !p.Spec.Actions.ReactivationAllowed {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// reactivate restores a fetched workload and clears its pause annotations
+func (e *Executor) reactivate(ctx context.Context, w *workload.Workload) error {
+	logger := log.FromContext(ctx)
+	key := w.Key()
+
+	// Verify it's actually paused
+	annotations := w.GetAnnotations()
+	if annotations["finops.io/paused"] != "true" {
+		return fmt.Errorf("%s %s/%s is not paused", strings.ToLower(string(key.Kind)), key.Namespace, key.Name)
+	}
+
+	// Restore original state
+	if err := restoreState(w); err != nil {
+		return err
+	}
+
+	// Clean up pause annotations
+	delete(annotations, "finops.io/paused")
+	delete(annotations, "finops.io/paused-at")
+	delete(annotations, "finops.io/reactivate-at")
+
+	// Keep original-replicas for historical tracking
+	annotations["finops.io/last-reactivation"] = time.Now().Format(time.RFC3339)
+	w.SetAnnotations(annotations)
+
+	// Update workload
+	if err := e.client.Update(ctx, w.Object); err != nil {
+		return fmt.Errorf("failed to reactivate %s: %w", strings.ToLower(string(key.Kind)), err)
+	}
+
+	logger.Info("successfully reactivated workload",
+		"kind", key.Kind,
+		"workload", key.Name,
+		"namespace", key.Namespace,
+		"replicas", w.Replicas(),
+		"suspended", w.Suspended(),
+	)
+	return nil
+}
+
+// AcknowledgeManualReactivation clears the pause annotations of a workload that
+// was restored outside FinOps Enforcer, leaving its spec untouched
+func (e *Executor) AcknowledgeManualReactivation(ctx context.Context, w *workload.Workload) error {
+	annotations := w.GetAnnotations()
+	delete(annotations, "finops.io/paused")
+	delete(annotations, "finops.io/paused-at")
+	delete(annotations, "finops.io/reactivate-at")
+	annotations["finops.io/last-reactivation"] = time.Now().Format(time.RFC3339)
+	w.SetAnnotations(annotations)
+
+	if err := e.client.Update(ctx, w.Object); err != nil {
+		return fmt.Errorf("failed to clear pause annotations on %s: %w", strings.ToLower(string(w.Kind)), err)
+	}
+
+	return nil
+}
+
+// restoreState restores replicas or suspend state from the pause annotations
+func restoreState(w *workload.Workload) error {
+	annotations := w.GetAnnotations()
+	if w.Kind.Suspendable() {
+		// Read prior suspend state
+		originalSuspendStr := annotations["finops.io/original-suspend"]
+		if originalSuspendStr == "" {
+			return fmt.Errorf("missing original-suspend annotation")
+		}
+
+		originalSuspend, err := strconv.ParseBool(originalSuspendStr)
+		if err != nil {
+			return fmt.Errorf("invalid original-suspend value: %w", err)
+		}
+
+		w.SetSuspended(originalSuspend)
+		return nil
+	}
+
+	// Read original replica count
+	originalReplicasStr := annotations["finops.io/original-replicas"]
+	if originalReplicasStr == "" {
+		return fmt.Errorf("missing original-replicas annotation")
+	}
+
+	originalReplicas, err := strconv.ParseInt(originalReplicasStr, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid original-replicas value: %w", err)
+	}
+
+	w.SetReplicas(int32(originalReplicas))
+	return nil
+}
+
+// GetPausedDeployments returns all deployments paused by FinOps Enforcer
+func (e *Executor) GetPausedDeployments(ctx context.Context, namespace string) ([]appsv1.Deployment, error) {
+	deploymentList := &appsv1.DeploymentList{}
+	listOpts := []client.ListOption{}
+	if namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+
+	if err := e.client.List(ctx, deploymentList, listOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	paused := []appsv1.Deployment{}
+	for _, deployment := range deploymentList.Items {
+		if deployment.Annotations["finops.io/paused"] == "true" {
+			paused = append(paused, deployment)
+		}
+	}
+
+	return paused, nil
+}
+
+// GetPausedWorkloads returns all workloads of every supported kind paused by FinOps Enforcer
+func (e *Executor) GetPausedWorkloads(ctx context.Context, namespace string) ([]*workload.Workload, error) {
+	listOpts := []client.ListOption{}
+	if namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+
+	paused := []*workload.Workload{}
+	for _, kind := range workload.SupportedKinds {
+		workloads, err := workload.List(ctx, e.client, kind, listOpts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range workloads {
+			if w.GetAnnotations()["finops.io/paused"] == "true" {
+				paused = append(paused, w)
+			}
+		}
+	}
+
+	return paused, nil
+}
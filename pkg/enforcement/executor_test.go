@@ -0,0 +1,210 @@
+package enforcement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReactivate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = finopsv1alpha1.AddToScheme(scheme)
+
+	newPolicy := func(namespace, name string, allowed bool) *finopsv1alpha1.EnforcementPolicy {
+		return &finopsv1alpha1.EnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Actions: finopsv1alpha1.ActionsSpec{ReactivationAllowed: allowed},
+			},
+		}
+	}
+	newPaused := func(name, policyName string) *appsv1.Deployment {
+		replicas := int32(0)
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "dev-team",
+				Annotations: map[string]string{
+					"finops.io/paused":                    "true",
+					"finops.io/paused-at":                 "2026-01-01T00:00:00Z",
+					"finops.io/policy":                    policyName,
+					"finops.io/original-replicas":         "3",
+					"finops.io/estimated-monthly-savings": "100.00",
+				},
+			},
+			Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newPolicy("finops-system", "open", true),
+		newPolicy("finops-system", "locked", false),
+		newPolicy("dev-team", "locked", true),
+		&finopsv1alpha1.ClusterEnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "open"},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Actions: finopsv1alpha1.ActionsSpec{ReactivationAllowed: false},
+			},
+		},
+		newPaused("api", "EnforcementPolicy/finops-system/open"),
+		newPaused("db", "EnforcementPolicy/finops-system/locked"),
+		newPaused("cache", "EnforcementPolicy/dev-team/locked"),
+		&finopsv1alpha1.ClusterEnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "frozen"},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Actions: finopsv1alpha1.ActionsSpec{ReactivationAllowed: false},
+			},
+		},
+		newPaused("web", "ClusterEnforcementPolicy/open"),
+		newPaused("legacy", "locked"),
+		newPaused("legacy-cluster", "frozen"),
+		newPaused("orphan", "EnforcementPolicy/finops-system/deleted-policy"),
+	).Build()
+	executor := NewExecutor(c)
+
+	tests := []struct {
+		name         string
+		workload     string
+		wantErr      error
+		wantReplicas int32
+	}{
+		{name: "allowed by policy", workload: "api", wantReplicas: 3},
+		{name: "disallowed by policy", workload: "db", wantErr: ErrReactivationNotAllowed},
+		{name: "same name in another namespace", workload: "cache", wantReplicas: 3},
+		{name: "disallowed by cluster policy", workload: "web", wantErr: ErrReactivationNotAllowed},
+		{name: "bare name disallowed by any policy of that name", workload: "legacy", wantErr: ErrReactivationNotAllowed},
+		{name: "bare name disallowed by cluster policy", workload: "legacy-cluster", wantErr: ErrReactivationNotAllowed},
+		{name: "policy deleted", workload: "orphan", wantReplicas: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := workload.Key{Kind: workload.KindDeployment, Namespace: "dev-team", Name: tt.workload}
+			w, err := executor.Reactivate(context.Background(), key, "test")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Reactivate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if w.Replicas() != tt.wantReplicas {
+				t.Errorf("Replicas() = %d, want %d", w.Replicas(), tt.wantReplicas)
+			}
+			if _, paused := w.GetAnnotations()["finops.io/paused"]; paused {
+				t.Error("expected paused annotation to be removed")
+			}
+		})
+	}
+}
+
+func TestScheduledPause(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-team"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+	executor := NewExecutor(c)
+	ctx := context.Background()
+	key := workload.Key{Kind: workload.KindDeployment, Namespace: "dev-team", Name: "api"}
+
+	w, err := workload.Get(ctx, c, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	scheduledAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	if err := executor.ExecuteAction(ctx, &policy.EnforcementAction{
+		Type:             finopsv1alpha1.ActionTypeScaleToZero,
+		Workload:         w,
+		OriginalReplicas: 3,
+		Warning:          true,
+		ScheduledAt:      scheduledAt,
+	}); err != nil {
+		t.Fatalf("ExecuteAction() error = %v", err)
+	}
+
+	w, _ = workload.Get(ctx, c, key)
+	if w.Replicas() != 3 {
+		t.Errorf("replicas = %d, want 3 during warning period", w.Replicas())
+	}
+	if got, _ := policy.PauseScheduledAt(w); !got.Equal(scheduledAt) {
+		t.Errorf("pause-scheduled-at = %v, want %v", got, scheduledAt)
+	}
+	if policy.PauseScheduledInDryRun(w) {
+		t.Error("enforcing warning marked as dry-run")
+	}
+
+	if err := executor.CancelScheduledPause(ctx, w); err != nil {
+		t.Fatalf("CancelScheduledPause() error = %v", err)
+	}
+	w, _ = workload.Get(ctx, c, key)
+	if _, scheduled := policy.PauseScheduledAt(w); scheduled {
+		t.Error("pause-scheduled-at still set after cancel")
+	}
+}
+
+func TestScheduledPauseDryRun(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-team"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+	executor := NewExecutor(c)
+	ctx := context.Background()
+	key := workload.Key{Kind: workload.KindDeployment, Namespace: "dev-team", Name: "api"}
+
+	w, err := workload.Get(ctx, c, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	scheduledAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	if err := executor.ExecuteAction(ctx, &policy.EnforcementAction{
+		Type:             finopsv1alpha1.ActionTypeScaleToZero,
+		Workload:         w,
+		OriginalReplicas: 3,
+		DryRun:           true,
+		Warning:          true,
+		ScheduledAt:      scheduledAt,
+	}); err != nil {
+		t.Fatalf("ExecuteAction() error = %v", err)
+	}
+
+	// The schedule is stamped so the workload is warned once per period
+	w, _ = workload.Get(ctx, c, key)
+	if w.Replicas() != 3 {
+		t.Errorf("replicas = %d, want 3 in dry-run", w.Replicas())
+	}
+	if got, _ := policy.PauseScheduledAt(w); !got.Equal(scheduledAt) {
+		t.Errorf("pause-scheduled-at = %v, want %v", got, scheduledAt)
+	}
+	if !policy.PauseScheduledInDryRun(w) {
+		t.Error("dry-run warning not marked as dry-run")
+	}
+
+	if err := executor.CancelScheduledPause(ctx, w); err != nil {
+		t.Fatalf("CancelScheduledPause() error = %v", err)
+	}
+	w, _ = workload.Get(ctx, c, key)
+	if _, scheduled := policy.PauseScheduledAt(w); scheduled || policy.PauseScheduledInDryRun(w) {
+		t.Errorf("annotations = %v, want schedule cleared", w.GetAnnotations())
+	}
+}
@@ -0,0 +1,228 @@
+package guardrail
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/metrics"
+)
+
+// budgetWindow is the sliding window the action budget is counted over
+const budgetWindow = time.Hour
+
+// Rate limit scopes recorded in finops_actions_rate_limited_total
+const (
+	ScopeCluster   = "cluster"
+	ScopeNamespace = "namespace"
+)
+
+// Circuit breaker trip reasons, also used as condition reasons
+const (
+	ReasonFalsePositiveRate = "FalsePositiveRateExceeded"
+	ReasonReactivationRate  = "ReactivationRateExceeded"
+)
+
+// Config configures a Guard. Zero limits and rates disable the check.
+type Config struct {
+	// MaxActionsPerHour caps pauses across the cluster in any hour
+	MaxActionsPerHour int
+
+	// MaxActionsPerNamespacePerHour caps pauses in one namespace in any hour
+	MaxActionsPerNamespacePerHour int
+
+	// BreakerWindow is the window false positive and reactivation rates are
+	// measured over
+	BreakerWindow time.Duration
+
+	// MaxFalsePositiveRate trips the breaker when false positives divided by
+	// pauses in the window exceed it
+	MaxFalsePositiveRate float64
+
+	// MaxReactivationRate trips the breaker when reactivations divided by
+	// pauses in the window exceed it
+	MaxReactivationRate float64
+
+	// MinPauses is the number of pauses in the window required before rates
+	// are evaluated, so a single early reactivation cannot trip the breaker
+	MinPauses int
+}
+
+// Guard is an in-memory sliding-window action budget and circuit breaker.
+// State is kept by the leader and resets on restart.
+type Guard struct {
+	config Config
+	now    func() time.Time
+
+	mu             sync.Mutex
+	actions        []action
+	pauses         []time.Time
+	reactivations  []time.Time
+	falsePositives []time.Time
+	open           bool
+	reason         string
+	message        string
+}
+
+// action is one pause counted against the budget
+type action struct {
+	namespace string
+	at        time.Time
+}
+
+// NewGuard creates a guard with the given limits
+func NewGuard(config Config) *Guard {
+	return &Guard{config: config, now: time.Now}
+}
+
+// Allow reports whether a pause in namespace fits the hourly budgets, and
+// which scope is exhausted if not. A nil guard allows everything.
+func (g *Guard) Allow(namespace string) (bool, string) {
+	if g == nil {
+		return true, ""
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.prune()
+
+	if g.config.MaxActionsPerHour > 0 && len(g.actions) >= g.config.MaxActionsPerHour {
+		metrics.ActionsRateLimited.WithLabelValues(ScopeCluster).Inc()
+		return false, ScopeCluster
+	}
+
+	if g.config.MaxActionsPerNamespacePerHour > 0 {
+		count := 0
+		for _, a := range g.actions {
+			if a.namespace == namespace {
+				count++
+			}
+		}
+		if count >= g.config.MaxActionsPerNamespacePerHour {
+			metrics.ActionsRateLimited.WithLabelValues(ScopeNamespace).Inc()
+			return false, ScopeNamespace
+		}
+	}
+
+	return true, ""
+}
+
+// RecordPause counts an executed pause against the budgets and breaker
+func (g *Guard) RecordPause(namespace string) {
+	if g == nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.actions = append(g.actions, action{namespace: namespace, at: now})
+	g.pauses = append(g.pauses, now)
+	g.evaluate()
+}
+
+// RecordReactivation counts a user or manual reactivation toward the breaker
+func (g *Guard) RecordReactivation() {
+	if g == nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.reactivations = append(g.reactivations, g.now())
+	g.evaluate()
+}
+
+// RecordFalsePositive counts a false positive toward the breaker
+func (g *Guard) RecordFalsePositive() {
+	if g == nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.falsePositives = append(g.falsePositives, g.now())
+	g.evaluate()
+}
+
+// Open reports whether the circuit breaker halts enforcement, with the trip
+// reason and a human-readable message. The breaker closes again once the
+// rates over the window drop below the thresholds.
+func (g *Guard) Open() (bool, string, string) {
+	if g == nil {
+		return false, "", ""
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.evaluate()
+	return g.open, g.reason, g.message
+}
+
+// evaluate recomputes the breaker state; callers hold the lock
+func (g *Guard) evaluate() {
+	g.prune()
+
+	open, reason, message := false, "", ""
+	pauses := len(g.pauses)
+	if pauses > 0 && pauses >= g.config.MinPauses {
+		falsePositiveRate := float64(len(g.falsePositives)) / float64(pauses)
+		reactivationRate := float64(len(g.reactivations)) / float64(pauses)
+
+		switch {
+		case g.config.MaxFalsePositiveRate > 0 && falsePositiveRate > g.config.MaxFalsePositiveRate:
+			open, reason = true, ReasonFalsePositiveRate
+			message = fmt.Sprintf("%d false positives for %d pauses in the last %s (rate %.2f > %.2f)",
+				len(g.falsePositives), pauses, g.config.BreakerWindow, falsePositiveRate, g.config.MaxFalsePositiveRate)
+		case g.config.MaxReactivationRate > 0 && reactivationRate > g.config.MaxReactivationRate:
+			open, reason = true, ReasonReactivationRate
+			message = fmt.Sprintf("%d reactivations for %d pauses in the last %s (rate %.2f > %.2f)",
+				len(g.reactivations), pauses, g.config.BreakerWindow, reactivationRate, g.config.MaxReactivationRate)
+		}
+	}
+
+	if open && !g.open {
+		metrics.CircuitBreakerTrips.WithLabelValues(reason).Inc()
+	}
+	g.open, g.reason, g.message = open, reason, message
+
+	if open {
+		metrics.CircuitBreakerOpen.Set(1)
+	} else {
+		metrics.CircuitBreakerOpen.Set(0)
+	}
+}
+
+// prune drops events older than their windows; callers hold the lock
+func (g *Guard) prune() {
+	now := g.now()
+
+	budgetCutoff := now.Add(-budgetWindow)
+	kept := g.actions[:0]
+	for _, a := range g.actions {
+		if a.at.After(budgetCutoff) {
+			kept = append(kept, a)
+		}
+	}
+	g.actions = kept
+
+	breakerCutoff := now.Add(-g.config.BreakerWindow)
+	g.pauses = pruneTimes(g.pauses, breakerCutoff)
+	g.reactivations = pruneTimes(g.reactivations, breakerCutoff)
+	g.falsePositives = pruneTimes(g.falsePositives, breakerCutoff)
+}
+
+// pruneTimes drops times at or before cutoff from an ordered slice
+func pruneTimes(times []time.Time, cutoff time.Time) []time.Time {
+	for i, t := range times {
+		if t.After(cutoff) {
+			return times[i:]
+		}
+	}
+	return times[:0]
+}
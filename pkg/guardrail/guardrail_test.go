@@ -0,0 +1,111 @@
+package guardrail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/yourusername/finops-enforcer/pkg/metrics"
+)
+
+// fakeClock is a controllable time source for guards
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestGuard(config Config) (*Guard, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)}
+	g := NewGuard(config)
+	g.now = clock.now
+	return g, clock
+}
+
+func TestAllow(t *testing.T) {
+	g, clock := newTestGuard(Config{MaxActionsPerHour: 3, MaxActionsPerNamespacePerHour: 2})
+
+	for _, namespace := range []string{"dev-a", "dev-a"} {
+		if allowed, scope := g.Allow(namespace); !allowed {
+			t.Fatalf("Allow(%s) = false (%s), want true", namespace, scope)
+		}
+		g.RecordPause(namespace)
+	}
+
+	if allowed, scope := g.Allow("dev-a"); allowed || scope != ScopeNamespace {
+		t.Errorf("Allow(dev-a) = %v, %q, want namespace budget exhausted", allowed, scope)
+	}
+	if allowed, _ := g.Allow("dev-b"); !allowed {
+		t.Error("Allow(dev-b) = false, want true")
+	}
+	g.RecordPause("dev-b")
+
+	if allowed, scope := g.Allow("dev-c"); allowed || scope != ScopeCluster {
+		t.Errorf("Allow(dev-c) = %v, %q, want cluster budget exhausted", allowed, scope)
+	}
+
+	// Budgets are a sliding hour
+	clock.t = clock.t.Add(time.Hour)
+	if allowed, scope := g.Allow("dev-a"); !allowed {
+		t.Errorf("Allow(dev-a) after an hour = false (%s), want true", scope)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	g, clock := newTestGuard(Config{
+		BreakerWindow:        time.Hour,
+		MaxFalsePositiveRate: 0.2,
+		MaxReactivationRate:  0.5,
+		MinPauses:            5,
+	})
+
+	// Too few pauses to evaluate rates
+	g.RecordPause("dev-a")
+	g.RecordReactivation()
+	g.RecordFalsePositive()
+	if open, _, _ := g.Open(); open {
+		t.Fatal("Open() = true before MinPauses, want false")
+	}
+
+	for i := 0; i < 4; i++ {
+		g.RecordPause("dev-a")
+	}
+	// 1 false positive for 5 pauses is at the threshold, not above it
+	if open, _, _ := g.Open(); open {
+		t.Fatal("Open() = true at threshold, want false")
+	}
+
+	g.RecordFalsePositive()
+	open, reason, message := g.Open()
+	if !open || reason != ReasonFalsePositiveRate {
+		t.Fatalf("Open() = %v, %q, want tripped by false positive rate", open, reason)
+	}
+	if message == "" {
+		t.Error("Open() message is empty")
+	}
+	if got := testutil.ToFloat64(metrics.CircuitBreakerOpen); got != 1 {
+		t.Errorf("finops_circuit_breaker_open = %v, want 1", got)
+	}
+
+	// The breaker closes once the events age out of the window
+	clock.t = clock.t.Add(time.Hour)
+	if open, _, _ := g.Open(); open {
+		t.Error("Open() = true after the window, want false")
+	}
+	if got := testutil.ToFloat64(metrics.CircuitBreakerOpen); got != 0 {
+		t.Errorf("finops_circuit_breaker_open = %v, want 0", got)
+	}
+}
+
+func TestNilGuard(t *testing.T) {
+	var g *Guard
+	g.RecordPause("dev-a")
+	g.RecordReactivation()
+	g.RecordFalsePositive()
+	if allowed, _ := g.Allow("dev-a"); !allowed {
+		t.Error("nil Allow() = false, want true")
+	}
+	if open, _, _ := g.Open(); open {
+		t.Error("nil Open() = true, want false")
+	}
+}
@@ -1,5 +1,4 @@
 package metrics
-package metrics
 
 import (
 	"github.com/prometheus/client_golang/prometheus"
@@ -25,124 +24,179 @@ var (
 		[]string{"namespace"},
 	)
 
+	// PolicyMatchesTotal counts policy evaluation matches
+	PolicyMatchesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "finops_policy_matches_total",
+			Help: "Number of times policies matched resources",
+		},
+		[]string{"policy", "action"},
+	)
 
+	// ActionsTakenTotal counts enforcement actions executed
+	ActionsTakenTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "finops_actions_taken_total",
+			Help: "Number of enforcement actions taken",
+		},
+		[]string{"action", "namespace", "dry_run"},
+	)
 
+	// ReactivationsTotal counts user-initiated reactivations
+	ReactivationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "finops_reactivations_total",
+			Help: "Number of user-initiated reactivations",
+		},
+		[]string{"namespace", "source"},
+	)
 
+	// FalsePositivesTotal tracks resources manually reactivated soon after being paused
+	FalsePositivesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "finops_false_positives_total",
+			Help: "Resources manually reactivated within the false positive window (default 1 hour)",
+		},
+		[]string{"namespace", "policy"},
+	)
 
+	// PolicyEvaluationDuration tracks time spent evaluating policies
+	PolicyEvaluationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "finops_policy_evaluation_duration_seconds",
+			Help:    "Time spent evaluating policies",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"policy"},
+	)
 
+	// ReconciliationDuration tracks overall reconciliation loop duration
+	ReconciliationDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "finops_reconciliation_duration_seconds",
+			Help:    "Time spent in reconciliation loop",
+			Buckets: prometheus.DefBuckets,
+		},
+	)
 
+	// OpenCostAPIErrors tracks OpenCost API failures
+	OpenCostAPIErrors = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "finops_opencost_api_errors_total",
+			Help: "Number of OpenCost API errors encountered",
+		},
+	)
 
+	// OpenCostCacheHits counts cost lookups served from the cache
+	OpenCostCacheHits = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "finops_opencost_cache_hits_total",
+			Help: "Number of cost lookups served from the OpenCost cache",
+		},
+	)
 
+	// OpenCostCacheMisses counts cost lookups that required an OpenCost query
+	OpenCostCacheMisses = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "finops_opencost_cache_misses_total",
+			Help: "Number of cost lookups that required an OpenCost API query",
+		},
+	)
 
+	// ActionsRateLimited counts pauses deferred by the cluster-wide action budget
+	ActionsRateLimited = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "finops_actions_rate_limited_total",
+			Help: "Number of enforcement actions deferred by the hourly action budget",
+		},
+		[]string{"scope"},
+	)
 
+	// CircuitBreakerOpen is 1 while the circuit breaker halts enforcement
+	CircuitBreakerOpen = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "finops_circuit_breaker_open",
+			Help: "Whether the circuit breaker is halting enforcement (1) or not (0)",
+		},
+	)
 
+	// CircuitBreakerTrips counts circuit breaker trips by reason
+	CircuitBreakerTrips = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "finops_circuit_breaker_trips_total",
+			Help: "Number of times the circuit breaker halted enforcement",
+		},
+		[]string{"reason"},
+	)
 
+	// PolicyEvaluationErrors tracks policy evaluation failures
+	PolicyEvaluationErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "finops_policy_evaluation_errors_total",
+			Help: "Number of policy evaluation errors",
+		},
+		[]string{"policy"},
+	)
+)
 
+func init() {
+	// Register metrics with controller-runtime
+	metrics.Registry.MustRegister(
+		PausedResourcesTotal,
+		EstimatedSavingsUSD,
+		PolicyMatchesTotal,
+		ActionsTakenTotal,
+		ReactivationsTotal,
+		FalsePositivesTotal,
+		PolicyEvaluationDuration,
+		ReconciliationDuration,
+		OpenCostAPIErrors,
+		OpenCostCacheHits,
+		OpenCostCacheMisses,
+		PolicyEvaluationErrors,
+		ActionsRateLimited,
+		CircuitBreakerOpen,
+		CircuitBreakerTrips,
+	)
+}
+
+// RecordPausedResource increments paused resources metric
+func RecordPausedResource(namespace, policy string, savings float64) {
+	PausedResourcesTotal.WithLabelValues(namespace, policy).Inc()
+	EstimatedSavingsUSD.WithLabelValues(namespace).Add(savings)
+}
+
+// Reactivation sources recorded in finops_reactivations_total
+const (
+	ReactivationSourceSlack    = "slack"
+	ReactivationSourceAPI      = "api"
+	ReactivationSourceManual   = "manual"
+	ReactivationSourceSchedule = "schedule"
+)
 
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-}	FalsePositivesTotal.WithLabelValues(namespace, policy).Inc()func RecordFalsePositive(namespace, policy string) {// RecordFalsePositive increments false positive counter}	ActionsTakenTotal.WithLabelValues(action, namespace, dryRunStr).Inc()	}		dryRunStr = "true"	if dryRun {	dryRunStr := "false"func RecordAction(action, namespace string, dryRun bool) {// RecordAction increments action counter}	PolicyMatchesTotal.WithLabelValues(policy, action).Inc()func RecordPolicyMatch(policy, action string) {// RecordPolicyMatch increments policy match counter}	EstimatedSavingsUSD.WithLabelValues(namespace).Sub(savings)	PausedResourcesTotal.WithLabelValues(namespace, "").Dec()	ReactivationsTotal.WithLabelValues(namespace, source).Inc()func RecordReactivation(namespace, source string, savings float64) {// RecordReactivation increments reactivation metric}	EstimatedSavingsUSD.WithLabelValues(namespace).Add(savings)	PausedResourcesTotal.WithLabelValues(namespace, policy).Inc()func RecordPausedResource(namespace, policy string, savings float64) {// RecordPausedResource increments paused resources metric}	)		PolicyEvaluationErrors,		OpenCostAPIErrors,		ReconciliationDuration,		PolicyEvaluationDuration,		FalsePositivesTotal,		ReactivationsTotal,		ActionsTakenTotal,		PolicyMatchesTotal,		EstimatedSavingsUSD,		PausedResourcesTotal,	metrics.Registry.MustRegister(	// Register metrics with controller-runtimefunc init() {)	)		[]string{"policy"},		},			Help: "Number of policy evaluation errors",			Name: "finops_policy_evaluation_errors_total",		prometheus.CounterOpts{	PolicyEvaluationErrors = prometheus.NewCounterVec(	// PolicyEvaluationErrors tracks policy evaluation failures	)		},			Help: "Number of OpenCost API errors encountered",			Name: "finops_opencost_api_errors_total",		prometheus.CounterOpts{	OpenCostAPIErrors = prometheus.NewCounter(	// OpenCostAPIErrors tracks OpenCost API failures	)		},			Buckets: prometheus.DefBuckets,			Help:    "Time spent in reconciliation loop",			Name:    "finops_reconciliation_duration_seconds",		prometheus.HistogramOpts{	ReconciliationDuration = prometheus.NewHistogram(	// ReconciliationDuration tracks overall reconciliation loop duration	)		[]string{"policy"},		},			Buckets: prometheus.DefBuckets,			Help:    "Time spent evaluating policies",			Name:    "finops_policy_evaluation_duration_seconds",		prometheus.HistogramOpts{	PolicyEvaluationDuration = prometheus.NewHistogramVec(	// PolicyEvaluationDuration tracks time spent evaluating policies	)		[]string{"namespace", "policy"},		},			Help: "Resources reactivated within 1 hour (likely false positive)",			Name: "finops_false_positives_total",		prometheus.CounterOpts{	FalsePositivesTotal = prometheus.NewCounterVec(	// FalsePositivesTotal tracks resources reactivated within 1 hour	)		[]string{"namespace", "source"},		},			Help: "Number of user-initiated reactivations",			Name: "finops_reactivations_total",		prometheus.CounterOpts{	ReactivationsTotal = prometheus.NewCounterVec(	// ReactivationsTotal counts user-initiated reactivations	)		[]string{"action", "namespace", "dry_run"},		},			Help: "Number of enforcement actions taken",			Name: "finops_actions_taken_total",		prometheus.CounterOpts{	ActionsTakenTotal = prometheus.NewCounterVec(	// ActionsTakenTotal counts enforcement actions executed	)		[]string{"policy", "action"},		},			Help: "Number of times policies matched resources",			Name: "finops_policy_matches_total",		prometheus.CounterOpts{	PolicyMatchesTotal = prometheus.NewCounterVec(	// PolicyMatchesTotal counts policy evaluation matches
\ No newline at end of file
+// RecordReactivation increments reactivation metric
+func RecordReactivation(namespace, policy, source string, savings float64) {
+	ReactivationsTotal.WithLabelValues(namespace, source).Inc()
+	PausedResourcesTotal.WithLabelValues(namespace, policy).Dec()
+	EstimatedSavingsUSD.WithLabelValues(namespace).Sub(savings)
+}
+
+// RecordPolicyMatch increments policy match counter
+func RecordPolicyMatch(policy, action string) {
+	PolicyMatchesTotal.WithLabelValues(policy, action).Inc()
+}
+
+// RecordAction increments action counter
+func RecordAction(action, namespace string, dryRun bool) {
+	dryRunStr := "false"
+	if dryRun {
+		dryRunStr = "true"
+	}
+
+	ActionsTakenTotal.WithLabelValues(action, namespace, dryRunStr).Inc()
+}
+
+// RecordFalsePositive increments false positive counter
+func RecordFalsePositive(namespace, policy string) {
+	FalsePositivesTotal.WithLabelValues(namespace, policy).Inc()
+}
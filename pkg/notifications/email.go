@@ -0,0 +1,199 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/digest"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+)
+
+// smtpTimeout bounds a single SMTP delivery when the caller's context has no
+// earlier deadline
+const smtpTimeout = 10 * time.Second
+
+// SMTPConfig configures the email notifier
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// EmailNotifier sends notifications by email over SMTP
+type EmailNotifier struct {
+	config SMTPConfig
+}
+
+// NewEmailNotifier creates a new SMTP email notifier
+func NewEmailNotifier(config SMTPConfig) *EmailNotifier {
+	return &EmailNotifier{config: config}
+}
+
+// NotifyPause sends a notification about a paused resource
+func (e *EmailNotifier) NotifyPause(ctx context.Context, action *policy.EnforcementAction, route Route) error {
+	w := action.Workload
+	recipients := e.config.To
+	if len(route.Emails) > 0 {
+		recipients = route.Emails
+	}
+
+	subject := fmt.Sprintf("[FinOps Enforcer] Paused %s %s/%s", w.Kind, w.GetNamespace(), w.GetName())
+	if action.Warning {
+		subject = fmt.Sprintf("[FinOps Enforcer] Scheduled pause of %s %s/%s", w.Kind, w.GetNamespace(), w.GetName())
+	}
+	if action.DryRun {
+		subject = "[FinOps Enforcer] DRY-RUN: " + strings.TrimPrefix(subject, "[FinOps Enforcer] ")
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\n\n%s\n\n", pauseTitle(action), action.Reason)
+	fmt.Fprintf(&body, "Namespace:                 %s\n", w.GetNamespace())
+	fmt.Fprintf(&body, "%-27s%s\n", string(w.Kind)+":", w.GetName())
+	fmt.Fprintf(&body, "Idle Duration:             %s\n", idleDuration(w))
+	fmt.Fprintf(&body, "Original State:            %s\n", originalState(action))
+	fmt.Fprintf(&body, "Estimated Monthly Savings: $%.2f\n", action.EstimatedMonthlySavings)
+	fmt.Fprintf(&body, "Policy:                    %s\n", action.Policy)
+	fmt.Fprintf(&body, "Routed To:                 %s\n", route.Describe(strings.Join(recipients, ", ")))
+	if action.Warning {
+		fmt.Fprintf(&body, "\n%s\n\nTo snooze for a week:\n\n    %s\n", warningText(action), snoozeCommand(action))
+	}
+	if !action.DryRun && !action.Warning {
+		fmt.Fprintf(&body, "\nTo reactivate manually:\n\n    %s\n", manualReactivationCommand(action))
+	}
+
+	return e.sendMail(ctx, recipients, subject, body.String())
+}
+
+// NotifyReactivation sends a notification about a reactivated resource
+func (e *EmailNotifier) NotifyReactivation(ctx context.Context, key workload.Key, replicas int32) error {
+	subject := fmt.Sprintf("[FinOps Enforcer] Reactivated %s %s/%s", key.Kind, key.Namespace, key.Name)
+	return e.sendMail(ctx, e.config.To, subject, reactivationText(key, replicas, "")+"\n")
+}
+
+// NotifyDigest sends a periodic activity summary of a policy
+func (e *EmailNotifier) NotifyDigest(ctx context.Context, d *digest.Digest, route Route) error {
+	recipients := e.config.To
+	if len(route.Emails) > 0 {
+		recipients = route.Emails
+	}
+
+	subject := fmt.Sprintf("[FinOps Enforcer] %s digest for policy %s", d.Frequency, d.Policy)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\n%s\n\n", digestTitle(d), digestPeriod(d))
+	for _, ns := range d.Namespaces {
+		fmt.Fprintf(&body, "%s: %s\n", ns.Namespace, countsSummary(ns.Counts))
+	}
+	fmt.Fprintf(&body, "\nTotal: %s\n", countsSummary(d.Totals))
+	fmt.Fprintf(&body, "Routed To: %s\n", route.Describe(strings.Join(recipients, ", ")))
+
+	return e.sendMail(ctx, recipients, subject, body.String())
+}
+
+// sendMail delivers a plain-text message to all recipients. The connection is
+// dialed with ctx and its deadline follows ctx, so a hung server cannot block
+// the caller past smtpTimeout or its own deadline.
+func (e *EmailNotifier) sendMail(ctx context.Context, to []string, subject, body string) error {
+	if len(to) == 0 {
+		return fmt.Errorf("no email recipients configured")
+	}
+
+	if err := e.deliver(ctx, to, buildEmail(e.config.From, to, subject, body)); err != nil {
+		return fmt.Errorf("failed to send email notification: %w", err)
+	}
+
+	return nil
+}
+
+// deliver runs one SMTP session, mirroring smtp.SendMail on a connection whose
+// lifetime is bound to ctx
+func (e *EmailNotifier) deliver(ctx context.Context, to []string, msg []byte) error {
+	addr := net.JoinHostPort(e.config.Host, strconv.Itoa(e.config.Port))
+	conn, err := (&net.Dialer{Timeout: smtpTimeout}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(smtpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	// Cancelling ctx expires the deadline so blocked reads and writes return
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, e.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if err := c.Hello("localhost"); err != nil {
+		return err
+	}
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: e.config.Host}); err != nil {
+			return err
+		}
+	}
+	if e.config.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return fmt.Errorf("smtp server does not support AUTH")
+		}
+		if err := c.Auth(smtp.PlainAuth("", e.config.Username, e.config.Password, e.config.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(e.config.From); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// buildEmail renders an RFC 5322 message with CRLF line endings
+func buildEmail(from string, to []string, subject, body string) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return msg.Bytes()
+}
@@ -0,0 +1,254 @@
+package notifications
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/enforcement"
+	"github.com/yourusername/finops-enforcer/pkg/metrics"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Interactive message identifiers used in pause notifications
+const (
+	pauseCallbackID      = "finops_pause"
+	reactivateActionName = "reactivate"
+	viewPolicyActionName = "view_policy"
+)
+
+// maxSignatureAge bounds replay of signed Slack requests
+const maxSignatureAge = 5 * time.Minute
+
+// maxInteractionBody limits the size of interaction payloads read into memory
+const maxInteractionBody = 1 << 20
+
+// Reactivator restores paused workloads on behalf of users
+type Reactivator interface {
+	Reactivate(ctx context.Context, key workload.Key, source string) (*workload.Workload, error)
+}
+
+// SlackInteractionHandler receives Slack interactivity callbacks for the
+// "Reactivate Now" button and replaces the original message with the outcome
+type SlackInteractionHandler struct {
+	signingSecret string
+	reactivator   Reactivator
+	httpClient    *http.Client
+	now           func() time.Time
+}
+
+// NewSlackInteractionHandler creates a handler verifying requests with the Slack app signing secret
+func NewSlackInteractionHandler(signingSecret string, reactivator Reactivator) *SlackInteractionHandler {
+	return &SlackInteractionHandler{
+		signingSecret: signingSecret,
+		reactivator:   reactivator,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		now: time.Now,
+	}
+}
+
+// SlackInteraction is the subset of a Slack interaction payload used by the handler.
+// Legacy attachment buttons set Name; Block Kit buttons set ActionID.
+type SlackInteraction struct {
+	Type        string                   `json:"type"`
+	User        SlackUser                `json:"user"`
+	Actions     []SlackInteractionAction `json:"actions"`
+	ResponseURL string                   `json:"response_url"`
+}
+
+// SlackUser identifies the user who clicked a button
+type SlackUser struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+// SlackInteractionAction is a clicked button
+type SlackInteractionAction struct {
+	Name     string `json:"name"`
+	ActionID string `json:"action_id"`
+	Value    string `json:"value"`
+}
+
+// ServeHTTP handles a Slack interaction request
+func (h *SlackInteractionHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	logger := log.FromContext(r.Context()).WithName("slack-interactions")
+
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxInteractionBody))
+	if err != nil {
+		http.Error(rw, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := VerifySlackSignature(h.signingSecret, r.Header, body, h.now()); err != nil {
+		logger.Info("rejected slack interaction", "reason", err.Error())
+		http.Error(rw, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	interaction, err := parseInteraction(body)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	value, ok := interaction.reactivateValue()
+	if !ok {
+		// Other buttons (e.g. View Policy) need no server-side handling
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+
+	message := h.reactivate(r.Context(), value, interaction.User)
+	message.ReplaceOriginal = true
+
+	if interaction.ResponseURL == "" {
+		rw.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(rw).Encode(message)
+		return
+	}
+
+	if err := postMessage(r.Context(), h.httpClient, interaction.ResponseURL, message); err != nil {
+		logger.Error(err, "failed to update slack message")
+	}
+	rw.WriteHeader(http.StatusOK)
+}
+
+// reactivate performs the reactivation and builds the replacement message
+func (h *SlackInteractionHandler) reactivate(ctx context.Context, value string, user SlackUser) *SlackMessage {
+	logger := log.FromContext(ctx).WithName("slack-interactions")
+
+	key, err := workload.ParseKey(value)
+	if err != nil {
+		return buildReactivationFailedMessage(value, err)
+	}
+
+	w, err := h.reactivator.Reactivate(ctx, key, metrics.ReactivationSourceSlack)
+	if err != nil {
+		logger.Error(err, "slack reactivation failed",
+			"kind", key.Kind,
+			"workload", key.Name,
+			"namespace", key.Namespace,
+			"user", user.display(),
+		)
+		if errors.Is(err, enforcement.ErrReactivationNotAllowed) {
+			err = fmt.Errorf("the policy that paused this %s does not allow reactivation", key.Kind)
+		}
+		return buildReactivationFailedMessage(value, err)
+	}
+
+	logger.Info("workload reactivated from slack",
+		"kind", key.Kind,
+		"workload", key.Name,
+		"namespace", key.Namespace,
+		"user", user.display(),
+	)
+
+	attachment := reactivationAttachment(key, w.Replicas())
+	attachment.Text += fmt.Sprintf("\nReactivated by %s.", user.display())
+	return &SlackMessage{Attachments: []SlackAttachment{attachment}}
+}
+
+// buildReactivationFailedMessage constructs a Slack message for a failed reactivation
+func buildReactivationFailedMessage(value string, err error) *SlackMessage {
+	return &SlackMessage{
+		Attachments: []SlackAttachment{
+			{
+				Color:     "#d00000",
+				Title:     "❌ Reactivation Failed",
+				Text:      fmt.Sprintf("Could not reactivate `%s`: %s", value, err),
+				Timestamp: time.Now().Unix(),
+				Footer:    "FinOps Enforcer",
+			},
+		},
+	}
+}
+
+// parseInteraction decodes the form-encoded payload field of an interaction request
+func parseInteraction(body []byte) (*SlackInteraction, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid form body: %w", err)
+	}
+
+	payload := form.Get("payload")
+	if payload == "" {
+		return nil, fmt.Errorf("missing payload")
+	}
+
+	interaction := &SlackInteraction{}
+	if err := json.Unmarshal([]byte(payload), interaction); err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+
+	return interaction, nil
+}
+
+// reactivateValue returns the value of the clicked "Reactivate Now" button
+func (i *SlackInteraction) reactivateValue() (string, bool) {
+	for _, action := range i.Actions {
+		if action.Name == reactivateActionName || action.ActionID == reactivateActionName {
+			return action.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// display returns a human-readable user reference
+func (u SlackUser) display() string {
+	if u.ID != "" {
+		return "<@" + u.ID + ">"
+	}
+	if u.Username != "" {
+		return u.Username
+	}
+	return u.Name
+}
+
+// VerifySlackSignature checks the v0 HMAC-SHA256 signature Slack attaches to requests
+func VerifySlackSignature(signingSecret string, header http.Header, body []byte, now time.Time) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	signature := header.Get("X-Slack-Signature")
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("missing signature headers")
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %w", err)
+	}
+
+	age := now.Sub(time.Unix(ts, 0))
+	if age > maxSignatureAge || age < -maxSignatureAge {
+		return fmt.Errorf("request timestamp too old")
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+
+	return nil
+}
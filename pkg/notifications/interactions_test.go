@@ -0,0 +1,189 @@
+package notifications
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/enforcement"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testSigningSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+// fakeReactivator records reactivation requests
+type fakeReactivator struct {
+	err    error
+	keys   []workload.Key
+	source string
+}
+
+func (f *fakeReactivator) Reactivate(ctx context.Context, key workload.Key, source string) (*workload.Workload, error) {
+	f.keys = append(f.keys, key)
+	f.source = source
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	replicas := int32(3)
+	return workload.FromDeployment(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}), nil
+}
+
+// signedRequest builds a Slack interaction request signed with testSigningSecret
+func signedRequest(t *testing.T, payload string, timestamp time.Time) *http.Request {
+	t.Helper()
+	body := url.Values{"payload": {payload}}.Encode()
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(testSigningSecret))
+	mac.Write([]byte("v0:" + ts + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/slack/interactions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestVerifySlackSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte("payload=%7B%7D")
+
+	sign := func(ts string) string {
+		mac := hmac.New(sha256.New, []byte(testSigningSecret))
+		mac.Write([]byte("v0:" + ts + ":" + string(body)))
+		return "v0=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		wantErr   bool
+	}{
+		{
+			name:      "valid",
+			timestamp: "1700000000",
+			signature: sign("1700000000"),
+		},
+		{
+			name:      "wrong signature",
+			timestamp: "1700000000",
+			signature: "v0=deadbeef",
+			wantErr:   true,
+		},
+		{
+			name:      "stale timestamp",
+			timestamp: "1699999000",
+			signature: sign("1699999000"),
+			wantErr:   true,
+		},
+		{
+			name:    "missing headers",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.timestamp != "" {
+				header.Set("X-Slack-Request-Timestamp", tt.timestamp)
+			}
+			if tt.signature != "" {
+				header.Set("X-Slack-Signature", tt.signature)
+			}
+
+			err := VerifySlackSignature(testSigningSecret, header, body, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifySlackSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlackInteractionHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		reactivateErr error
+		wantTitle     string
+	}{
+		{
+			name:      "reactivated",
+			wantTitle: "✅ Resource Reactivated",
+		},
+		{
+			name:          "not allowed by policy",
+			reactivateErr: enforcement.ErrReactivationNotAllowed,
+			wantTitle:     "❌ Reactivation Failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated SlackMessage
+			responseServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+					t.Errorf("failed to decode response_url message: %v", err)
+				}
+			}))
+			defer responseServer.Close()
+
+			reactivator := &fakeReactivator{err: tt.reactivateErr}
+			handler := NewSlackInteractionHandler(testSigningSecret, reactivator)
+
+			payload := `{"type":"interactive_message","callback_id":"finops_pause",
+				"user":{"id":"U123","name":"dev"},
+				"actions":[{"name":"reactivate","type":"button","value":"dev-team/api"}],
+				"response_url":"` + responseServer.URL + `"}`
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, signedRequest(t, payload, time.Now()))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", rec.Code)
+			}
+			if len(reactivator.keys) != 1 || reactivator.keys[0].String() != "dev-team/api" {
+				t.Errorf("reactivated keys = %v, want [dev-team/api]", reactivator.keys)
+			}
+			if reactivator.source != "slack" {
+				t.Errorf("source = %q, want slack", reactivator.source)
+			}
+			if !updated.ReplaceOriginal {
+				t.Error("expected original message to be replaced")
+			}
+			if len(updated.Attachments) != 1 || updated.Attachments[0].Title != tt.wantTitle {
+				t.Errorf("attachments = %+v, want title %q", updated.Attachments, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestSlackInteractionHandlerRejectsInvalidSignature(t *testing.T) {
+	reactivator := &fakeReactivator{}
+	handler := NewSlackInteractionHandler("other-secret", reactivator)
+
+	payload := `{"actions":[{"name":"reactivate","value":"dev-team/api"}]}`
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(t, payload, time.Now()))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", rec.Code)
+	}
+	if len(reactivator.keys) != 0 {
+		t.Errorf("unexpected reactivation: %v", reactivator.keys)
+	}
+}
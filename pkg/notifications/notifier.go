@@ -0,0 +1,155 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/digest"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+)
+
+// Notifier delivers enforcement notifications to a single backend
+type Notifier interface {
+	// NotifyPause sends a notification about a paused resource to the
+	// route's destinations, falling back to the notifier's defaults
+	NotifyPause(ctx context.Context, action *policy.EnforcementAction, route Route) error
+
+	// NotifyReactivation sends a notification about a reactivated resource
+	NotifyReactivation(ctx context.Context, key workload.Key, replicas int32) error
+
+	// NotifyDigest sends a periodic activity summary of a policy to the
+	// route's destinations, falling back to the notifier's defaults
+	NotifyDigest(ctx context.Context, d *digest.Digest, route Route) error
+}
+
+// pauseTitle returns the headline of a pause notification
+func pauseTitle(action *policy.EnforcementAction) string {
+	if action.Warning && action.DryRun {
+		return "🧪 DRY-RUN: Would Schedule Pause of Idle Resource"
+	}
+	if action.Warning {
+		return "⏰ Idle Resource Will Be Paused"
+	}
+	if action.DryRun {
+		return "🧪 DRY-RUN: Would Pause Idle Resource"
+	}
+
+	return "🚨 Idle Resource Paused"
+}
+
+// idleDuration formats how long a workload has been idle
+func idleDuration(w *workload.Workload) string {
+	if lastActivity := policy.LastActivity(w); !lastActivity.IsZero() {
+		return formatDuration(time.Since(lastActivity))
+	}
+
+	return "Unknown"
+}
+
+// originalState describes what a pause action will restore
+func originalState(action *policy.EnforcementAction) string {
+	if action.Workload.Kind.Suspendable() {
+		return "active schedule"
+	}
+
+	return fmt.Sprintf("%d replicas", action.OriginalReplicas)
+}
+
+// manualReactivationCommand returns the kubectl command that undoes an action
+func manualReactivationCommand(action *policy.EnforcementAction) string {
+	w := action.Workload
+	if w.Kind.Suspendable() {
+		return fmt.Sprintf("kubectl patch %s %s -n %s -p '{\"spec\":{\"suspend\":false}}'",
+			strings.ToLower(string(w.Kind)), w.GetName(), w.GetNamespace())
+	}
+
+	return fmt.Sprintf("kubectl scale %s %s -n %s --replicas=%d",
+		strings.ToLower(string(w.Kind)), w.GetName(), w.GetNamespace(), action.OriginalReplicas)
+}
+
+// warningText tells owners when a warned workload will be paused
+func warningText(action *policy.EnforcementAction) string {
+	verb := "paused"
+	if action.Workload.Kind.Suspendable() {
+		verb = "suspended"
+	}
+
+	return fmt.Sprintf("This %s will be %s at %s unless it becomes active.",
+		action.Workload.Kind, verb, action.ScheduledAt.UTC().Format(time.RFC1123))
+}
+
+// snoozeCommand returns the kubectl command that postpones a scheduled pause by a week
+func snoozeCommand(action *policy.EnforcementAction) string {
+	w := action.Workload
+	return fmt.Sprintf("kubectl annotate %s %s -n %s --overwrite finops.io/snooze-until=%s",
+		strings.ToLower(string(w.Kind)), w.GetName(), w.GetNamespace(),
+		action.ScheduledAt.Add(7*24*time.Hour).UTC().Format(time.RFC3339))
+}
+
+// digestTitle returns the headline of a digest notification
+func digestTitle(d *digest.Digest) string {
+	frequency := "Daily"
+	if d.Frequency == finopsv1alpha1.DigestWeekly {
+		frequency = "Weekly"
+	}
+
+	return fmt.Sprintf("📊 %s FinOps Digest: %s", frequency, d.Policy)
+}
+
+// digestPeriod formats the period covered by a digest
+func digestPeriod(d *digest.Digest) string {
+	const layout = "Jan 2 15:04"
+	return fmt.Sprintf("%s – %s UTC", d.PeriodStart.UTC().Format(layout), d.PeriodEnd.UTC().Format(layout))
+}
+
+// countsSummary describes activity counts in one line
+func countsSummary(c digest.Counts) string {
+	return fmt.Sprintf("%d paused, %d reactivated (%d false positives), net savings $%.2f/month",
+		c.Pauses, c.Reactivations, c.FalsePositives, c.EstimatedSavings)
+}
+
+// reactivationText describes a reactivated workload; code wraps identifiers
+// in the backend's inline code markup
+func reactivationText(key workload.Key, replicas int32, code string) string {
+	if key.Kind.Suspendable() {
+		return fmt.Sprintf("%s %s%s%s in namespace %s%s%s has been resumed.",
+			key.Kind, code, key.Name, code, code, key.Namespace, code)
+	}
+
+	return fmt.Sprintf("%s %s%s%s in namespace %s%s%s has been restored to %d replicas.",
+		key.Kind, code, key.Name, code, code, key.Namespace, code, replicas)
+}
+
+// postJSON posts a JSON payload to a webhook and expects a 2xx response
+func postJSON(ctx context.Context, httpClient *http.Client, backend, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s message: %w", backend, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", backend, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s api error: status=%d", backend, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,345 @@
+package notifications
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/digest"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Compile-time checks that every backend implements Notifier
+var (
+	_ Notifier = &SlackNotifier{}
+	_ Notifier = &TeamsNotifier{}
+	_ Notifier = &EmailNotifier{}
+	_ Notifier = &WebhookNotifier{}
+)
+
+func testPauseAction() *policy.EnforcementAction {
+	return &policy.EnforcementAction{
+		Type: finopsv1alpha1.ActionTypeScaleToZero,
+		Workload: workload.FromDeployment(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-team"},
+		}),
+		OriginalReplicas:        3,
+		Reason:                  "Idle for 48h",
+		EstimatedMonthlySavings: 120.5,
+		Policy:                  "dev-idle",
+	}
+}
+
+// captureServer records the JSON bodies posted to it
+func captureServer(t *testing.T, status int, bodies *[]map[string]interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		*bodies = append(*bodies, body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTeamsNotifier(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := captureServer(t, http.StatusAccepted, &bodies)
+
+	notifier := NewTeamsNotifier(server.URL)
+	if err := notifier.NotifyPause(context.Background(), testPauseAction(), Route{Source: RouteSourceDefault}); err != nil {
+		t.Fatalf("NotifyPause() error = %v", err)
+	}
+
+	if len(bodies) != 1 {
+		t.Fatalf("requests = %d, want 1", len(bodies))
+	}
+	attachments, _ := bodies[0]["attachments"].([]interface{})
+	if len(attachments) != 1 {
+		t.Fatalf("attachments = %v, want 1 adaptive card", bodies[0]["attachments"])
+	}
+	attachment := attachments[0].(map[string]interface{})
+	if attachment["contentType"] != adaptiveCardContentType {
+		t.Errorf("contentType = %v, want %s", attachment["contentType"], adaptiveCardContentType)
+	}
+	content := attachment["content"].(map[string]interface{})
+	if content["type"] != "AdaptiveCard" {
+		t.Errorf("content type = %v, want AdaptiveCard", content["type"])
+	}
+	encoded, _ := json.Marshal(content)
+	if !strings.Contains(string(encoded), "kubectl scale deployment api -n dev-team --replicas=3") {
+		t.Errorf("card does not contain manual reactivation command: %s", encoded)
+	}
+}
+
+func TestTeamsNotifierError(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := captureServer(t, http.StatusBadRequest, &bodies)
+
+	notifier := NewTeamsNotifier(server.URL)
+	if err := notifier.NotifyPause(context.Background(), testPauseAction(), Route{Source: RouteSourceDefault}); err == nil {
+		t.Fatal("NotifyPause() expected error for 400 response")
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := captureServer(t, http.StatusOK, &bodies)
+
+	notifier := NewWebhookNotifier(server.URL)
+	if err := notifier.NotifyPause(context.Background(), testPauseAction(), Route{Source: RouteSourceDefault}); err != nil {
+		t.Fatalf("NotifyPause() error = %v", err)
+	}
+	key := workload.Key{Kind: workload.KindDeployment, Namespace: "dev-team", Name: "api"}
+	if err := notifier.NotifyReactivation(context.Background(), key, 3); err != nil {
+		t.Fatalf("NotifyReactivation() error = %v", err)
+	}
+
+	tests := []struct {
+		field string
+		index int
+		want  interface{}
+	}{
+		{field: "event", index: 0, want: WebhookEventPause},
+		{field: "kind", index: 0, want: "Deployment"},
+		{field: "policy", index: 0, want: "dev-idle"},
+		{field: "originalReplicas", index: 0, want: float64(3)},
+		{field: "estimatedMonthlySavings", index: 0, want: 120.5},
+		{field: "event", index: 1, want: WebhookEventReactivation},
+		{field: "replicas", index: 1, want: float64(3)},
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("requests = %d, want 2", len(bodies))
+	}
+	for _, tt := range tests {
+		if got := bodies[tt.index][tt.field]; got != tt.want {
+			t.Errorf("request %d %s = %v, want %v", tt.index, tt.field, got, tt.want)
+		}
+	}
+}
+
+// fakeSMTPServer accepts a single SMTP session and returns the recipients and message
+func fakeSMTPServer(t *testing.T) (string, <-chan []string, <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	recipients := make(chan []string, 1)
+	messages := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+		var rcpt []string
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "MAIL FROM"):
+				reply("250 OK")
+			case strings.HasPrefix(command, "RCPT TO"):
+				rcpt = append(rcpt, strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>"))
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				recipients <- rcpt
+				messages <- data.String()
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), recipients, messages
+}
+
+func TestEmailNotifier(t *testing.T) {
+	addr, recipients, messages := fakeSMTPServer(t)
+	host, portStr, _ := net.SplitHostPort(addr)
+	port, _ := strconv.Atoi(portStr)
+
+	notifier := NewEmailNotifier(SMTPConfig{
+		Host: host,
+		Port: port,
+		From: "finops@example.com",
+		To:   []string{"team-a@example.com", "team-b@example.com"},
+	})
+	if err := notifier.NotifyPause(context.Background(), testPauseAction(), Route{Source: RouteSourceDefault}); err != nil {
+		t.Fatalf("NotifyPause() error = %v", err)
+	}
+
+	if got := <-recipients; strings.Join(got, ",") != "team-a@example.com,team-b@example.com" {
+		t.Errorf("recipients = %v", got)
+	}
+
+	message := <-messages
+	for _, want := range []string{
+		"Subject: [FinOps Enforcer] Paused Deployment dev-team/api",
+		"Estimated Monthly Savings: $120.50",
+		"kubectl scale deployment api -n dev-team --replicas=3",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("message missing %q:\n%s", want, message)
+		}
+	}
+}
+
+func TestEmailNotifierWithoutRecipients(t *testing.T) {
+	notifier := NewEmailNotifier(SMTPConfig{Host: "127.0.0.1", Port: 25})
+	if err := notifier.NotifyPause(context.Background(), testPauseAction(), Route{Source: RouteSourceDefault}); err == nil {
+		t.Fatal("NotifyPause() expected error without recipients")
+	}
+}
+
+func TestEmailNotifierHonorsContextDeadline(t *testing.T) {
+	// The server accepts connections but never sends its greeting
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+
+	host, portStr, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	notifier := NewEmailNotifier(SMTPConfig{
+		Host: host,
+		Port: port,
+		From: "finops@example.com",
+		To:   []string{"team-a@example.com"},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := notifier.NotifyPause(ctx, testPauseAction(), Route{Source: RouteSourceDefault}); err == nil {
+		t.Fatal("NotifyPause() expected error from a hung server")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("NotifyPause() returned after %v, want it bounded by the context deadline", elapsed)
+	}
+}
+
+func TestSlackNotifierRouting(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := captureServer(t, http.StatusOK, &bodies)
+
+	notifier := NewSlackNotifier(server.URL, "#finops-alerts")
+	routes := []Route{
+		{Source: RouteSourceDefault},
+		{Team: "payments", SlackChannel: "#payments", Source: "namespace team payments"},
+	}
+	for _, route := range routes {
+		if err := notifier.NotifyPause(context.Background(), testPauseAction(), route); err != nil {
+			t.Fatalf("NotifyPause() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		wantChannel  string
+		wantRoutedTo string
+	}{
+		{wantChannel: "#finops-alerts", wantRoutedTo: "#finops-alerts (default)"},
+		{wantChannel: "#payments", wantRoutedTo: "#payments (namespace team payments)"},
+	}
+
+	if len(bodies) != len(tests) {
+		t.Fatalf("requests = %d, want %d", len(bodies), len(tests))
+	}
+	for i, tt := range tests {
+		if got := bodies[i]["channel"]; got != tt.wantChannel {
+			t.Errorf("request %d channel = %v, want %v", i, got, tt.wantChannel)
+		}
+		encoded, _ := json.Marshal(bodies[i]["attachments"])
+		if !strings.Contains(string(encoded), tt.wantRoutedTo) {
+			t.Errorf("request %d missing routed to %q: %s", i, tt.wantRoutedTo, encoded)
+		}
+	}
+}
+
+func TestWebhookNotifierDigest(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := captureServer(t, http.StatusOK, &bodies)
+
+	d := &digest.Digest{
+		Policy:    "dev-idle",
+		Frequency: finopsv1alpha1.DigestWeekly,
+		Namespaces: []digest.NamespaceCounts{
+			{Namespace: "dev-a", Counts: digest.Counts{Pauses: 2, EstimatedSavings: 80}},
+		},
+		Totals: digest.Counts{Pauses: 2, EstimatedSavings: 80},
+	}
+	route := Route{Team: "payments", SlackChannel: "#payments", Source: "namespace team payments"}
+	if err := NewWebhookNotifier(server.URL).NotifyDigest(context.Background(), d, route); err != nil {
+		t.Fatalf("NotifyDigest() error = %v", err)
+	}
+
+	if len(bodies) != 1 {
+		t.Fatalf("requests = %d, want 1", len(bodies))
+	}
+	body := bodies[0]
+	if body["event"] != WebhookEventDigest || body["policy"] != "dev-idle" || body["frequency"] != "weekly" {
+		t.Errorf("digest event = %v", body)
+	}
+	if body["team"] != "payments" || body["slackChannel"] != "#payments" {
+		t.Errorf("digest route = %v/%v, want payments/#payments", body["team"], body["slackChannel"])
+	}
+	totals, _ := body["totals"].(map[string]interface{})
+	if totals["pauses"] != float64(2) {
+		t.Errorf("totals = %v, want 2 pauses", body["totals"])
+	}
+}
@@ -0,0 +1,219 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Owner metadata read from workloads and their namespaces
+const (
+	OwnerSlackChannelAnnotation = "finops.io/owner-slack-channel"
+	OwnerEmailAnnotation        = "finops.io/owner-email"
+	TeamAnnotation              = "finops.io/team"
+	TeamLabel                   = "team"
+)
+
+// Team mapping ConfigMap key suffixes, e.g. "payments.slackChannel"
+const (
+	teamSlackChannelSuffix = ".slackChannel"
+	teamEmailSuffix        = ".email"
+)
+
+// RouteSourceDefault marks notifications sent to the controller-wide destinations
+const RouteSourceDefault = "default"
+
+// Route is where notifications about a workload are delivered. Empty
+// destinations fall back to the notifier's defaults.
+type Route struct {
+	Team         string
+	SlackChannel string
+	Emails       []string
+
+	// Source describes how the route was chosen, e.g. "namespace team payments"
+	Source string
+}
+
+// Describe formats a destination and the routing decision for messages
+func (r Route) Describe(destination string) string {
+	if r.Source == "" || r.Source == RouteSourceDefault {
+		return destination + " (default)"
+	}
+
+	return fmt.Sprintf("%s (%s)", destination, r.Source)
+}
+
+// TeamRoute holds the destinations configured for a team
+type TeamRoute struct {
+	SlackChannel string
+	Emails       []string
+}
+
+// Router resolves notification routes from owner metadata on workloads and
+// namespaces and a team mapping ConfigMap
+type Router struct {
+	reader    client.Reader
+	configMap client.ObjectKey
+	refresh   time.Duration
+
+	mu       sync.Mutex
+	teams    map[string]TeamRoute
+	loadedAt time.Time
+}
+
+// NewRouter creates a router reading namespaces and the team mapping
+// ConfigMap through reader. The mapping is reloaded at most once per refresh
+// interval.
+func NewRouter(reader client.Reader, configMap client.ObjectKey, refresh time.Duration) *Router {
+	return &Router{
+		reader:    reader,
+		configMap: configMap,
+		refresh:   refresh,
+	}
+}
+
+// Route returns the destinations for a workload. Owner metadata on the
+// workload wins over its namespace; a nil router routes everything to the
+// defaults.
+func (r *Router) Route(ctx context.Context, w *workload.Workload) Route {
+	if r == nil {
+		return Route{Source: RouteSourceDefault}
+	}
+
+	if route, ok := r.routeFor(ctx, w, "workload"); ok {
+		return route
+	}
+
+	return r.RouteNamespace(ctx, w.GetNamespace())
+}
+
+// RouteNamespace returns the destinations for activity in a namespace as a
+// whole, e.g. its part of a digest, from the namespace's owner metadata
+func (r *Router) RouteNamespace(ctx context.Context, name string) Route {
+	if r == nil {
+		return Route{Source: RouteSourceDefault}
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := r.reader.Get(ctx, client.ObjectKey{Name: name}, namespace); err != nil {
+		log.FromContext(ctx).Error(err, "failed to get namespace for notification routing", "namespace", name)
+	} else if route, ok := r.routeFor(ctx, namespace, "namespace"); ok {
+		return route
+	}
+
+	return Route{Source: RouteSourceDefault}
+}
+
+// routeFor builds a route from the owner annotations and team of one object
+func (r *Router) routeFor(ctx context.Context, obj metav1.Object, owner string) (Route, bool) {
+	annotations := obj.GetAnnotations()
+	route := Route{
+		SlackChannel: annotations[OwnerSlackChannelAnnotation],
+		Emails:       splitAddresses(annotations[OwnerEmailAnnotation]),
+	}
+	if route.SlackChannel != "" || len(route.Emails) > 0 {
+		route.Source = owner + " annotation"
+	}
+
+	route.Team = annotations[TeamAnnotation]
+	if route.Team == "" {
+		route.Team = obj.GetLabels()[TeamLabel]
+	}
+	if route.Team == "" {
+		return route, route.Source != ""
+	}
+
+	teamRoute, ok := r.teamRoute(ctx, route.Team)
+	if !ok {
+		return route, route.Source != ""
+	}
+
+	if route.SlackChannel == "" {
+		route.SlackChannel = teamRoute.SlackChannel
+	}
+	if len(route.Emails) == 0 {
+		route.Emails = teamRoute.Emails
+	}
+	if route.Source == "" {
+		route.Source = fmt.Sprintf("%s team %s", owner, route.Team)
+	}
+
+	return route, true
+}
+
+// teamRoute looks up a team in the mapping ConfigMap
+func (r *Router) teamRoute(ctx context.Context, team string) (TeamRoute, bool) {
+	teams, err := r.loadTeams(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to load team notification mapping", "configmap", r.configMap)
+		return TeamRoute{}, false
+	}
+
+	route, ok := teams[team]
+	return route, ok
+}
+
+// loadTeams returns the cached team mapping, re-reading the ConfigMap when stale.
+// A missing ConfigMap is an empty mapping.
+func (r *Router) loadTeams(ctx context.Context) (map[string]TeamRoute, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.teams != nil && time.Since(r.loadedAt) < r.refresh {
+		return r.teams, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.reader.Get(ctx, r.configMap, configMap); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get team mapping %s: %w", r.configMap, err)
+	}
+
+	r.teams = ParseTeamRoutes(configMap.Data)
+	r.loadedAt = time.Now()
+
+	return r.teams, nil
+}
+
+// ParseTeamRoutes parses team mapping ConfigMap data with keys
+// "<team>.slackChannel" and "<team>.email" (comma-separated addresses)
+func ParseTeamRoutes(data map[string]string) map[string]TeamRoute {
+	teams := make(map[string]TeamRoute)
+	for key, value := range data {
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasSuffix(key, teamSlackChannelSuffix):
+			team := strings.TrimSuffix(key, teamSlackChannelSuffix)
+			route := teams[team]
+			route.SlackChannel = value
+			teams[team] = route
+		case strings.HasSuffix(key, teamEmailSuffix):
+			team := strings.TrimSuffix(key, teamEmailSuffix)
+			route := teams[team]
+			route.Emails = splitAddresses(value)
+			teams[team] = route
+		}
+	}
+
+	return teams
+}
+
+// splitAddresses splits a comma-separated address list
+func splitAddresses(s string) []string {
+	var addresses []string
+	for _, address := range strings.Split(s, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
@@ -0,0 +1,116 @@
+package notifications
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRouterRoute(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "finops-owner-routing", Namespace: "finops-system"},
+			Data: map[string]string{
+				"payments.slackChannel": "#payments",
+				"payments.email":        "payments@example.com, oncall@example.com",
+				"search.slackChannel":   "#search",
+			},
+		},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments-dev", Labels: map[string]string{"team": "payments"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "shared-dev",
+			Annotations: map[string]string{OwnerSlackChannelAnnotation: "#shared"},
+		}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unowned-dev"}},
+	).Build()
+	router := NewRouter(c, client.ObjectKey{Namespace: "finops-system", Name: "finops-owner-routing"}, time.Minute)
+
+	newWorkload := func(namespace string, labels, annotations map[string]string) *workload.Workload {
+		return workload.FromDeployment(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace, Labels: labels, Annotations: annotations},
+		})
+	}
+
+	tests := []struct {
+		name     string
+		workload *workload.Workload
+		want     Route
+	}{
+		{
+			name:     "namespace team label",
+			workload: newWorkload("payments-dev", nil, nil),
+			want: Route{
+				Team:         "payments",
+				SlackChannel: "#payments",
+				Emails:       []string{"payments@example.com", "oncall@example.com"},
+				Source:       "namespace team payments",
+			},
+		},
+		{
+			name:     "workload team wins over namespace",
+			workload: newWorkload("payments-dev", map[string]string{"team": "search"}, nil),
+			want:     Route{Team: "search", SlackChannel: "#search", Source: "workload team search"},
+		},
+		{
+			name: "workload annotation overrides team channel",
+			workload: newWorkload("payments-dev", map[string]string{"team": "payments"},
+				map[string]string{OwnerSlackChannelAnnotation: "#payments-api"}),
+			want: Route{
+				Team:         "payments",
+				SlackChannel: "#payments-api",
+				Emails:       []string{"payments@example.com", "oncall@example.com"},
+				Source:       "workload annotation",
+			},
+		},
+		{
+			name:     "namespace annotation",
+			workload: newWorkload("shared-dev", nil, nil),
+			want:     Route{SlackChannel: "#shared", Source: "namespace annotation"},
+		},
+		{
+			name:     "unmapped team falls back to namespace",
+			workload: newWorkload("payments-dev", nil, map[string]string{TeamAnnotation: "unknown"}),
+			want: Route{
+				Team:         "payments",
+				SlackChannel: "#payments",
+				Emails:       []string{"payments@example.com", "oncall@example.com"},
+				Source:       "namespace team payments",
+			},
+		},
+		{
+			name:     "no owner metadata",
+			workload: newWorkload("unowned-dev", nil, nil),
+			want:     Route{Source: RouteSourceDefault},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := router.Route(context.Background(), tt.workload)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Route() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNilRouter(t *testing.T) {
+	var router *Router
+	w := workload.FromDeployment(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev"}})
+	if got := router.Route(context.Background(), w); got.Source != RouteSourceDefault {
+		t.Errorf("Route() = %+v, want default", got)
+	}
+}
@@ -0,0 +1,158 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/digest"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+)
+
+// adaptiveCardContentType identifies Adaptive Card attachments in Teams messages
+const adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+
+// TeamsNotifier sends notifications to a Microsoft Teams incoming webhook
+type TeamsNotifier struct {
+	webhookURL string
+	httpClient *http.Client
+}
+
+// NewTeamsNotifier creates a new Microsoft Teams notifier
+func NewTeamsNotifier(webhookURL string) *TeamsNotifier {
+	return &TeamsNotifier{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// NotifyPause sends a notification about a paused resource
+// Teams incoming webhooks are bound to one channel, so the route is not used.
+func (t *TeamsNotifier) NotifyPause(ctx context.Context, action *policy.EnforcementAction, route Route) error {
+	return t.sendCard(ctx, buildPauseCard(action))
+}
+
+// NotifyReactivation sends a notification about a reactivated resource
+func (t *TeamsNotifier) NotifyReactivation(ctx context.Context, key workload.Key, replicas int32) error {
+	return t.sendCard(ctx, AdaptiveCard{
+		Body: []CardElement{
+			{Type: "TextBlock", Text: "✅ Resource Reactivated", Weight: "Bolder", Size: "Medium", Color: "Good"},
+			{Type: "TextBlock", Text: reactivationText(key, replicas, "**"), Wrap: true},
+		},
+	})
+}
+
+// NotifyDigest sends a periodic activity summary of a policy
+// Teams incoming webhooks are bound to one channel, so the route is not used.
+func (t *TeamsNotifier) NotifyDigest(ctx context.Context, d *digest.Digest, route Route) error {
+	facts := make([]CardFact, 0, len(d.Namespaces))
+	for _, ns := range d.Namespaces {
+		facts = append(facts, CardFact{Title: ns.Namespace, Value: countsSummary(ns.Counts)})
+	}
+
+	return t.sendCard(ctx, AdaptiveCard{
+		Body: []CardElement{
+			{Type: "TextBlock", Text: digestTitle(d), Weight: "Bolder", Size: "Medium", Color: "Accent"},
+			{Type: "TextBlock", Text: digestPeriod(d), Wrap: true},
+			{Type: "FactSet", Facts: facts},
+			{Type: "TextBlock", Text: "**Total:** " + countsSummary(d.Totals), Wrap: true},
+		},
+	})
+}
+
+// buildPauseCard constructs an Adaptive Card for pause notifications
+func buildPauseCard(action *policy.EnforcementAction) AdaptiveCard {
+	w := action.Workload
+
+	card := AdaptiveCard{
+		Body: []CardElement{
+			{Type: "TextBlock", Text: pauseTitle(action), Weight: "Bolder", Size: "Medium", Color: "Warning"},
+			{Type: "TextBlock", Text: action.Reason, Wrap: true},
+			{
+				Type: "FactSet",
+				Facts: []CardFact{
+					{Title: "Namespace", Value: w.GetNamespace()},
+					{Title: string(w.Kind), Value: w.GetName()},
+					{Title: "Idle Duration", Value: idleDuration(w)},
+					{Title: "Original State", Value: originalState(action)},
+					{Title: "Estimated Monthly Savings", Value: fmt.Sprintf("$%.2f", action.EstimatedMonthlySavings)},
+					{Title: "Policy", Value: action.Policy},
+				},
+			},
+		},
+	}
+
+	if action.Warning {
+		card.Body = append(card.Body,
+			CardElement{Type: "TextBlock", Text: "**" + warningText(action) + "**", Wrap: true},
+			CardElement{Type: "TextBlock", Text: "**To snooze for a week:**", Wrap: true},
+			CardElement{Type: "TextBlock", Text: snoozeCommand(action), FontType: "Monospace", Wrap: true},
+		)
+	}
+
+	// Incoming webhooks cannot receive callbacks, so only the manual command is offered
+	if !action.DryRun && !action.Warning {
+		card.Body = append(card.Body,
+			CardElement{Type: "TextBlock", Text: "**To reactivate manually:**", Wrap: true},
+			CardElement{Type: "TextBlock", Text: manualReactivationCommand(action), FontType: "Monospace", Wrap: true},
+		)
+	}
+
+	return card
+}
+
+// sendCard wraps an Adaptive Card in a Teams message and posts it
+func (t *TeamsNotifier) sendCard(ctx context.Context, card AdaptiveCard) error {
+	card.Schema = "http://adaptivecards.io/schemas/adaptive-card.json"
+	card.Type = "AdaptiveCard"
+	card.Version = "1.4"
+
+	return postJSON(ctx, t.httpClient, "teams", t.webhookURL, TeamsMessage{
+		Type: "message",
+		Attachments: []TeamsAttachment{
+			{ContentType: adaptiveCardContentType, Content: card},
+		},
+	})
+}
+
+// TeamsMessage represents a Teams incoming webhook message
+type TeamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+// TeamsAttachment carries an Adaptive Card in a Teams message
+type TeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard represents an Adaptive Card
+type AdaptiveCard struct {
+	Schema  string        `json:"$schema"`
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Body    []CardElement `json:"body"`
+}
+
+// CardElement represents a TextBlock or FactSet element of an Adaptive Card
+type CardElement struct {
+	Type     string     `json:"type"`
+	Text     string     `json:"text,omitempty"`
+	Weight   string     `json:"weight,omitempty"`
+	Size     string     `json:"size,omitempty"`
+	Color    string     `json:"color,omitempty"`
+	FontType string     `json:"fontType,omitempty"`
+	Wrap     bool       `json:"wrap,omitempty"`
+	Facts    []CardFact `json:"facts,omitempty"`
+}
+
+// CardFact represents a fact in a FactSet
+type CardFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
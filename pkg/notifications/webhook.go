@@ -0,0 +1,117 @@
+package notifications
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/digest"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+)
+
+// Webhook event types
+const (
+	WebhookEventPause        = "pause"
+	WebhookEventWarning      = "warning"
+	WebhookEventReactivation = "reactivation"
+	WebhookEventDigest       = "digest"
+)
+
+// WebhookNotifier posts notifications as JSON to an arbitrary HTTP endpoint
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a new generic webhook notifier
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url: url,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// NotifyPause sends a notification about a paused resource
+func (n *WebhookNotifier) NotifyPause(ctx context.Context, action *policy.EnforcementAction, route Route) error {
+	w := action.Workload
+
+	event := WebhookEvent{
+		Event:                   WebhookEventPause,
+		Kind:                    string(w.Kind),
+		Namespace:               w.GetNamespace(),
+		Name:                    w.GetName(),
+		Policy:                  action.Policy,
+		Action:                  string(action.Type),
+		Reason:                  action.Reason,
+		DryRun:                  action.DryRun,
+		OriginalReplicas:        action.OriginalReplicas,
+		EstimatedMonthlySavings: action.EstimatedMonthlySavings,
+		Team:                    route.Team,
+		SlackChannel:            route.SlackChannel,
+		Emails:                  route.Emails,
+		Timestamp:               time.Now().UTC().Format(time.RFC3339),
+	}
+	if action.Warning {
+		event.Event = WebhookEventWarning
+		event.ScheduledAt = action.ScheduledAt.UTC().Format(time.RFC3339)
+	}
+
+	return postJSON(ctx, n.httpClient, "webhook", n.url, event)
+}
+
+// NotifyReactivation sends a notification about a reactivated resource
+func (n *WebhookNotifier) NotifyReactivation(ctx context.Context, key workload.Key, replicas int32) error {
+	return postJSON(ctx, n.httpClient, "webhook", n.url, WebhookEvent{
+		Event:     WebhookEventReactivation,
+		Kind:      string(key.Kind),
+		Namespace: key.Namespace,
+		Name:      key.Name,
+		Replicas:  replicas,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// NotifyDigest sends a periodic activity summary of a policy
+func (n *WebhookNotifier) NotifyDigest(ctx context.Context, d *digest.Digest, route Route) error {
+	return postJSON(ctx, n.httpClient, "webhook", n.url, WebhookDigestEvent{
+		Event:        WebhookEventDigest,
+		Digest:       *d,
+		Team:         route.Team,
+		SlackChannel: route.SlackChannel,
+		Emails:       route.Emails,
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// WebhookDigestEvent is the JSON body posted by WebhookNotifier for digests
+type WebhookDigestEvent struct {
+	Event string `json:"event"`
+	digest.Digest
+	Team         string   `json:"team,omitempty"`
+	SlackChannel string   `json:"slackChannel,omitempty"`
+	Emails       []string `json:"emails,omitempty"`
+	Timestamp    string   `json:"timestamp"`
+}
+
+// WebhookEvent is the JSON body posted by WebhookNotifier
+type WebhookEvent struct {
+	Event                   string   `json:"event"`
+	Kind                    string   `json:"kind"`
+	Namespace               string   `json:"namespace"`
+	Name                    string   `json:"name"`
+	Policy                  string   `json:"policy,omitempty"`
+	Action                  string   `json:"action,omitempty"`
+	Reason                  string   `json:"reason,omitempty"`
+	DryRun                  bool     `json:"dryRun,omitempty"`
+	OriginalReplicas        int32    `json:"originalReplicas,omitempty"`
+	Replicas                int32    `json:"replicas,omitempty"`
+	EstimatedMonthlySavings float64  `json:"estimatedMonthlySavings,omitempty"`
+	ScheduledAt             string   `json:"scheduledAt,omitempty"`
+	Team                    string   `json:"team,omitempty"`
+	SlackChannel            string   `json:"slackChannel,omitempty"`
+	Emails                  []string `json:"emails,omitempty"`
+	Timestamp               string   `json:"timestamp"`
+}
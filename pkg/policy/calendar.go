@@ -0,0 +1,310 @@
+package policy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Calendar ConfigMap data keys
+const (
+	// CalendarDatesKey holds one entry per line: "2026-12-25 Christmas Day"
+	// or an inclusive range "2026-12-21..2027-01-04 Release freeze"
+	CalendarDatesKey = "dates"
+
+	// CalendarICSKey holds iCalendar (.ics) content with VEVENT entries
+	CalendarICSKey = "calendar.ics"
+)
+
+const dateLayout = "2006-01-02"
+
+// CalendarSource loads calendars referenced by policy schedules
+type CalendarSource interface {
+	Calendar(ctx context.Context, namespace, name string) (*Calendar, error)
+}
+
+// Calendar is a named list of dated entries
+type Calendar struct {
+	Name    string
+	Entries []CalendarEntry
+}
+
+// CalendarEntry is a period from Start (inclusive) to End (exclusive). Floating
+// entries, such as all-day dates, hold wall-clock times in UTC and are
+// compared in the policy timezone.
+type CalendarEntry struct {
+	Summary  string
+	Start    time.Time
+	End      time.Time
+	Floating bool
+}
+
+// EntryAt returns the first entry covering t, using loc for floating entries
+func (c *Calendar) EntryAt(t time.Time, loc *time.Location) (CalendarEntry, bool) {
+	local := t.In(loc)
+	wallClock := time.Date(local.Year(), local.Month(), local.Day(),
+		local.Hour(), local.Minute(), local.Second(), 0, time.UTC)
+
+	for _, entry := range c.Entries {
+		at := t
+		if entry.Floating {
+			at = wallClock
+		}
+		if !at.Before(entry.Start) && at.Before(entry.End) {
+			return entry, true
+		}
+	}
+
+	return CalendarEntry{}, false
+}
+
+// ParseCalendar parses calendar ConfigMap data from the dates and
+// calendar.ics keys
+func ParseCalendar(name string, data map[string]string) (*Calendar, error) {
+	_, hasDates := data[CalendarDatesKey]
+	_, hasICS := data[CalendarICSKey]
+	if !hasDates && !hasICS {
+		return nil, fmt.Errorf("calendar %s has neither %q nor %q data", name, CalendarDatesKey, CalendarICSKey)
+	}
+
+	calendar := &Calendar{Name: name}
+
+	if dates, ok := data[CalendarDatesKey]; ok {
+		entries, err := parseDates(dates)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %s: %w", name, err)
+		}
+		calendar.Entries = append(calendar.Entries, entries...)
+	}
+
+	if ics, ok := data[CalendarICSKey]; ok {
+		entries, err := parseICS(ics)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %s: %w", name, err)
+		}
+		calendar.Entries = append(calendar.Entries, entries...)
+	}
+
+	return calendar, nil
+}
+
+// parseDates parses the line-based date format; blank lines and lines
+// starting with # are ignored
+func parseDates(data string) ([]CalendarEntry, error) {
+	var entries []CalendarEntry
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		dates, summary, _ := strings.Cut(line, " ")
+		first, last, isRange := strings.Cut(dates, "..")
+		if !isRange {
+			last = first
+		}
+
+		start, err := time.Parse(dateLayout, first)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q, expected YYYY-MM-DD", lineNumber, first)
+		}
+		end, err := time.Parse(dateLayout, last)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q, expected YYYY-MM-DD", lineNumber, last)
+		}
+		if end.Before(start) {
+			return nil, fmt.Errorf("line %d: range ends before it starts", lineNumber)
+		}
+
+		summary = strings.TrimSpace(summary)
+		if summary == "" {
+			summary = dates
+		}
+
+		entries = append(entries, CalendarEntry{
+			Summary:  summary,
+			Start:    start,
+			End:      end.AddDate(0, 0, 1),
+			Floating: true,
+		})
+	}
+
+	return entries, scanner.Err()
+}
+
+// parseICS parses the VEVENT entries of iCalendar content. Only DTSTART,
+// DTEND and SUMMARY are used; recurrence rules are not supported.
+func parseICS(data string) ([]CalendarEntry, error) {
+	var entries []CalendarEntry
+	var event *CalendarEntry
+	hasEnd := false
+
+	for i, line := range unfoldICS(data) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		property, params, _ := strings.Cut(name, ";")
+
+		switch strings.ToUpper(property) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				event = &CalendarEntry{}
+				hasEnd = false
+			}
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || event == nil {
+				continue
+			}
+			if event.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: VEVENT without DTSTART", i+1)
+			}
+			if !hasEnd {
+				// An event without DTEND lasts one day
+				event.End = event.Start.AddDate(0, 0, 1)
+			}
+			if event.Summary == "" {
+				event.Summary = event.Start.Format(dateLayout)
+			}
+			entries = append(entries, *event)
+			event = nil
+		case "DTSTART", "DTEND":
+			if event == nil {
+				continue
+			}
+			t, floating, err := parseICSTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			if strings.EqualFold(property, "DTSTART") {
+				event.Start, event.Floating = t, floating
+			} else {
+				event.End, hasEnd = t, true
+			}
+		case "SUMMARY":
+			if event != nil {
+				event.Summary = unescapeICS(value)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// parseICSTime parses a DATE or DATE-TIME value. Dates and times without a
+// zone are floating; TZID parameters and a trailing Z fix the instant.
+func parseICSTime(params, value string) (time.Time, bool, error) {
+	var loc *time.Location
+	for _, param := range strings.Split(params, ";") {
+		key, tzid, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "TZID") {
+			l, err := time.LoadLocation(strings.Trim(tzid, `"`))
+			if err != nil {
+				return time.Time{}, false, fmt.Errorf("invalid TZID %q: %w", tzid, err)
+			}
+			loc = l
+		}
+	}
+
+	switch {
+	case len(value) == 8:
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+		}
+		return t, true, nil
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+		}
+		return t, false, nil
+	case loc != nil:
+		t, err := time.ParseInLocation("20060102T150405", value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+		}
+		return t, false, nil
+	default:
+		t, err := time.Parse("20060102T150405", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+		}
+		return t, true, nil
+	}
+}
+
+// unfoldICS joins continuation lines, which start with a space or tab
+func unfoldICS(data string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, "\r"))
+	}
+	return lines
+}
+
+// unescapeICS reverses iCalendar TEXT escaping
+func unescapeICS(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+// ConfigMapCalendars loads calendars from ConfigMaps in the policy namespace
+type ConfigMapCalendars struct {
+	reader  client.Reader
+	refresh time.Duration
+
+	mu        sync.Mutex
+	calendars map[client.ObjectKey]cachedCalendar
+}
+
+type cachedCalendar struct {
+	calendar *Calendar
+	loadedAt time.Time
+}
+
+// NewConfigMapCalendars creates a calendar source reading ConfigMaps through
+// reader. Calendars are reloaded at most once per refresh interval.
+func NewConfigMapCalendars(reader client.Reader, refresh time.Duration) *ConfigMapCalendars {
+	return &ConfigMapCalendars{
+		reader:    reader,
+		refresh:   refresh,
+		calendars: make(map[client.ObjectKey]cachedCalendar),
+	}
+}
+
+// Calendar returns the parsed calendar ConfigMap namespace/name
+func (c *ConfigMapCalendars) Calendar(ctx context.Context, namespace, name string) (*Calendar, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.calendars[key]; ok && time.Since(cached.loadedAt) < c.refresh {
+		return cached.calendar, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := c.reader.Get(ctx, key, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get calendar %s: %w", key, err)
+	}
+
+	calendar, err := ParseCalendar(name, configMap.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	c.calendars[key] = cachedCalendar{calendar: calendar, loadedAt: time.Now()}
+	return calendar, nil
+}
@@ -0,0 +1,114 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20261225\r\n" +
+	"DTEND;VALUE=DATE:20261227\r\n" +
+	"SUMMARY:Christmas\\, Boxing Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=Europe/London:20261120T180000\r\n" +
+	"DTEND;TZID=Europe/London:20261123T090000\r\n" +
+	"SUMMARY:Release 4.2\r\n" +
+	"  freeze\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestCalendarEntryAt(t *testing.T) {
+	calendar, err := ParseCalendar("holidays", map[string]string{
+		CalendarDatesKey: "# UK bank holidays\n2026-08-31 Summer bank holiday\n2026-12-21..2026-12-23 Office closed\n",
+		CalendarICSKey:   testICS,
+	})
+	if err != nil {
+		t.Fatalf("ParseCalendar() error = %v", err)
+	}
+
+	london, _ := time.LoadLocation("Europe/London")
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	tests := []struct {
+		name        string
+		at          time.Time
+		loc         *time.Location
+		wantSummary string
+	}{
+		{name: "single date", at: time.Date(2026, 8, 31, 12, 0, 0, 0, london), loc: london, wantSummary: "Summer bank holiday"},
+		{name: "day after single date", at: time.Date(2026, 9, 1, 0, 0, 0, 0, london), loc: london},
+		{name: "last day of range", at: time.Date(2026, 12, 23, 23, 59, 0, 0, london), loc: london, wantSummary: "Office closed"},
+		{name: "ics all-day", at: time.Date(2026, 12, 26, 8, 0, 0, 0, london), loc: london, wantSummary: "Christmas, Boxing Day"},
+		{name: "ics all-day ends", at: time.Date(2026, 12, 27, 0, 0, 0, 0, london), loc: london},
+		// 2026-12-24 23:00 in London is already Christmas Day in Tokyo
+		{name: "floating dates use policy timezone", at: time.Date(2026, 12, 24, 23, 0, 0, 0, london), loc: tokyo, wantSummary: "Christmas, Boxing Day"},
+		{name: "ics date-time with tzid", at: time.Date(2026, 11, 20, 18, 30, 0, 0, time.UTC), loc: tokyo, wantSummary: "Release 4.2 freeze"},
+		{name: "ics date-time ended", at: time.Date(2026, 11, 23, 9, 0, 0, 0, time.UTC), loc: london},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, ok := calendar.EntryAt(tt.at, tt.loc)
+			if ok != (tt.wantSummary != "") {
+				t.Fatalf("EntryAt() found = %v, want %v", ok, tt.wantSummary != "")
+			}
+			if entry.Summary != tt.wantSummary {
+				t.Errorf("Summary = %q, want %q", entry.Summary, tt.wantSummary)
+			}
+		})
+	}
+}
+
+func TestParseCalendarErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]string
+	}{
+		{name: "no data", data: map[string]string{"other": "x"}},
+		{name: "bad date", data: map[string]string{CalendarDatesKey: "31/12/2026 New Year's Eve"}},
+		{name: "reversed range", data: map[string]string{CalendarDatesKey: "2026-12-31..2026-12-01 Backwards"}},
+		{name: "ics without start", data: map[string]string{CalendarICSKey: "BEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\n"}},
+		{name: "ics bad tzid", data: map[string]string{CalendarICSKey: "BEGIN:VEVENT\nDTSTART;TZID=Nowhere/Else:20261225T090000\nEND:VEVENT\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCalendar("broken", tt.data); err == nil {
+				t.Error("ParseCalendar() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestConfigMapCalendars(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-freeze", Namespace: "finops-system"},
+		Data:       map[string]string{CalendarDatesKey: "2026-12-14..2027-01-04 Year-end freeze"},
+	}).Build()
+	calendars := NewConfigMapCalendars(c, time.Minute)
+
+	calendar, err := calendars.Calendar(context.Background(), "finops-system", "release-freeze")
+	if err != nil {
+		t.Fatalf("Calendar() error = %v", err)
+	}
+	if len(calendar.Entries) != 1 || calendar.Entries[0].Summary != "Year-end freeze" {
+		t.Errorf("Entries = %+v, want one Year-end freeze entry", calendar.Entries)
+	}
+
+	if _, err := calendars.Calendar(context.Background(), "other", "release-freeze"); err == nil {
+		t.Error("Calendar() in another namespace error = nil, want not found")
+	}
+}
@@ -1,265 +1,657 @@
 package policy
-package policy
 
 import (
 	"context"
-	"path/filepath"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
 	"github.com/yourusername/finops-enforcer/pkg/cost"
-	appsv1 "k8s.io/api/apps/v1"
+	"github.com/yourusername/finops-enforcer/pkg/scope"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Engine evaluates enforcement policies against resources
 type Engine struct {
 	// Dependencies injected at initialization
+	traffic     TrafficSource
+	utilization UtilizationSource
+	calendars   CalendarSource
+	namespaces  client.Reader
+
+	// Compiled policy expressions by policy
+	mu          sync.Mutex
+	expressions map[string]compiledExpressions
 }
 
-// NewEngine creates a new policy engine
-func NewEngine() *Engine {
-	return &Engine{}
+// TrafficSource reports the observed request rate of a workload
+type TrafficSource interface {
+	RequestsPerMinute(ctx context.Context, queryTemplate string, w *workload.Workload, window time.Duration) (float64, error)
 }
 
-// EvaluationResult represents the result of policy evaluation
-type EvaluationResult struct {
-	Policy     *finopsv1alpha1.EnforcementPolicy
-	Deployment *appsv1.Deployment
-	CostData   *cost.CostData
-	Matched    bool
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
-
+// UtilizationSource reports average CPU and memory utilization of a workload
+// as percentages of its resource requests
+type UtilizationSource interface {
+	Utilization(ctx context.Context, w *workload.Workload, window time.Duration) (cpu float64, memory float64, err error)
+}
 
+// Option configures an Engine
+type Option func(*Engine)
 
+// WithTrafficSource enables trafficThreshold evaluation
+func WithTrafficSource(source TrafficSource) Option {
+	return func(e *Engine) {
+		e.traffic = source
+	}
+}
 
+// WithUtilizationSource enables utilizationThreshold evaluation
+func WithUtilizationSource(source UtilizationSource) Option {
+	return func(e *Engine) {
+		e.utilization = source
+	}
+}
 
+// WithCalendarSource enables schedule calendars
+func WithCalendarSource(source CalendarSource) Option {
+	return func(e *Engine) {
+		e.calendars = source
+	}
+}
 
+// WithNamespaceReader lets namespace selectors, namespace annotations and
+// policy expressions read the workload's namespace. Namespaces are read for
+// every evaluated workload, so reader should be cached.
+func WithNamespaceReader(reader client.Reader) Option {
+	return func(e *Engine) {
+		e.namespaces = reader
+	}
+}
 
+// NewEngine creates a new policy engine
+func NewEngine(opts ...Option) *Engine {
+	e := &Engine{expressions: make(map[string]compiledExpressions)}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
 
+// EvaluationResult represents the result of policy evaluation
+type EvaluationResult struct {
+	Policy   *finopsv1alpha1.EnforcementPolicy
+	Workload *workload.Workload
+	CostData *cost.CostData
+	Matched  bool
+	Reason   string
+	Action   *EnforcementAction
+
+	// RequestsPerMinute is the observed traffic, set when trafficThreshold is evaluated
+	RequestsPerMinute *float64
+
+	// CPUUtilization and MemoryUtilization are observed percentages of requests,
+	// set when utilizationThreshold is evaluated
+	CPUUtilization    *float64
+	MemoryUtilization *float64
+
+	// PauseScheduledAt is when a warned workload will be paused, set when the
+	// policy has a warning period and the workload is idle
+	PauseScheduledAt *time.Time
+
+	// CancelScheduledPause is set when a workload with a scheduled pause no
+	// longer matches, so the schedule should be cleared
+	CancelScheduledPause bool
+}
 
+// EnforcementAction represents an action to be taken
+type EnforcementAction struct {
+	Type                    finopsv1alpha1.ActionType
+	Workload                *workload.Workload
+	OriginalReplicas        int32
+	Reason                  string
+	EstimatedMonthlySavings float64
+	DryRun                  bool
+
+	// Policy is the Ref of the policy taking the action, stored in the
+	// finops.io/policy annotation
+	Policy string
+
+	// Warning marks a pre-pause warning: the workload is only notified and
+	// stamped with finops.io/pause-scheduled-at=ScheduledAt, also in dry-run
+	// so that it is warned once per warning period
+	Warning     bool
+	ScheduledAt time.Time
+}
 
+// Evaluate evaluates a workload against a policy
+func (e *Engine) Evaluate(
+	ctx context.Context,
+	policy *finopsv1alpha1.EnforcementPolicy,
+	w *workload.Workload,
+	costData *cost.CostData,
+) (*EvaluationResult, error) {
+	result, err := e.evaluate(ctx, policy, w, costData)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelVoidPause(result)
+	return result, nil
+}
 
+// Screen runs the checks of Evaluate that need no cost data: annotations,
+// kind, scope and the scope expression. It returns the result of the first
+// check that fails, or nil when the workload passes and Evaluate must decide,
+// so cost is only looked up for workloads that could be paused.
+func (e *Engine) Screen(
+	ctx context.Context,
+	policy *finopsv1alpha1.EnforcementPolicy,
+	w *workload.Workload,
+) (*EvaluationResult, error) {
+	result, _, _, err := e.screen(ctx, policy, w)
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	cancelVoidPause(result)
+	return result, nil
+}
 
+// cancelVoidPause marks a scheduled pause for cancellation, since it is void
+// once the workload stops matching
+func cancelVoidPause(result *EvaluationResult) {
+	if _, scheduled := PauseScheduledAt(result.Workload); scheduled && !result.Matched && !isPaused(result.Workload) {
+		result.CancelScheduledPause = true
+	}
+}
 
+// evaluate runs the policy checks in order and stops at the first that fails
+func (e *Engine) evaluate(
+	ctx context.Context,
+	policy *finopsv1alpha1.EnforcementPolicy,
+	w *workload.Workload,
+	costData *cost.CostData,
+) (*EvaluationResult, error) {
+	result, namespace, expressions, err := e.screen(ctx, policy, w)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil {
+		result.CostData = costData
+		return result, nil
+	}
+
+	result = &EvaluationResult{
+		Policy:   policy,
+		Workload: w,
+		CostData: costData,
+		Matched:  false,
+	}
+
+	// Check cost threshold
+	if costData.HourlyCost < policy.Spec.Conditions.MinHourlyCost {
+		result.Reason = "cost below threshold"
+		return result, nil
+	}
+
+	// Check idle window
+	if !e.isIdleLongEnough(w, policy.Spec.Conditions.IdleWindow.Duration) {
+		result.Reason = "not idle long enough"
+		return result, nil
+	}
+
+	// Check schedule (if defined)
+	scheduleReason := ""
+	if policy.Spec.Schedule != nil {
+		active, reason, err := e.isWithinSchedule(ctx, policy.Namespace, policy.Spec.Schedule, time.Now())
+		if err != nil {
+			result.Reason = fmt.Sprintf("invalid schedule: %v", err)
+			return result, nil
+		}
+		if !active {
+			result.Reason = reason
+			return result, nil
+		}
+		scheduleReason = reason
+	}
+
+	// Check cooldown
+	if policy.Spec.Enforcement.CooldownWindow.Duration > 0 {
+		if !e.isCooldownExpired(w, policy.Spec.Enforcement.CooldownWindow.Duration) {
+			result.Reason = "cooldown not expired"
+			return result, nil
+		}
+	}
+
+	// Check conditions expression
+	idle, err := expressions.MatchConditions(w, namespace, costData)
+	if err != nil {
+		return nil, fmt.Errorf("conditions expression: %w", err)
+	}
+	if !idle {
+		result.Reason = "conditions expression not matched"
+		return result, nil
+	}
+
+	// Check traffic threshold last since it queries Prometheus
+	if threshold := policy.Spec.Conditions.TrafficThreshold; threshold != nil {
+		if e.traffic == nil {
+			result.Reason = "traffic source not configured"
+			return result, nil
+		}
+
+		rate, err := e.traffic.RequestsPerMinute(ctx, threshold.Query, w, policy.Spec.Conditions.IdleWindow.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query traffic: %w", err)
+		}
+		result.RequestsPerMinute = &rate
+
+		if rate > float64(threshold.RequestsPerMinute) {
+			result.Reason = fmt.Sprintf("traffic above threshold (%s req/min)", formatFloat(rate))
+			return result, nil
+		}
+	}
+
+	// Check utilization threshold
+	if threshold := policy.Spec.Conditions.UtilizationThreshold; threshold != nil && (threshold.CPU != "" || threshold.Memory != "") {
+		reason, err := e.checkUtilization(ctx, threshold, w, policy.Spec.Conditions.IdleWindow.Duration, result)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			result.Reason = reason
+			return result, nil
+		}
+	}
+
+	// All conditions matched - create action
+	result.Matched = true
+	result.Reason = buildMatchReason(policy, result)
+	if scheduleReason != "" {
+		result.Reason += ", " + scheduleReason
+	}
+	action := &EnforcementAction{
+		Type:                    policy.Spec.Actions.Type,
+		Workload:                w,
+		OriginalReplicas:        w.Replicas(),
+		Reason:                  result.Reason,
+		EstimatedMonthlySavings: cost.EstimateMonthlyCost(costData.HourlyCost),
+		Policy:                  Candidate{Kind: KindEnforcementPolicy, Namespace: policy.Namespace, Name: policy.Name}.Ref(),
+		DryRun:                  policy.Spec.Enforcement.IsDryRun(),
+	}
+
+	// Warn first and act only once the warning period has elapsed
+	if warningPeriod := policy.Spec.Actions.WarningPeriod.Duration; warningPeriod > 0 {
+		scheduledAt, scheduled := PauseScheduledAt(w)
+		// A schedule stamped in dry-run never warned of a real pause
+		if scheduled && !action.DryRun && PauseScheduledInDryRun(w) {
+			scheduled = false
+		}
+		switch {
+		case !scheduled:
+			scheduledAt = time.Now().Add(warningPeriod)
+			action.Warning = true
+			action.ScheduledAt = scheduledAt
+			result.PauseScheduledAt = &scheduledAt
+		case time.Now().Before(scheduledAt):
+			result.PauseScheduledAt = &scheduledAt
+			result.Reason = fmt.Sprintf("pause scheduled at %s", scheduledAt.Format(time.RFC3339))
+			return result, nil
+		}
+	}
+
+	result.Action = action
+	return result, nil
+}
 
+// screen runs the checks that need no cost data and returns the result of
+// the first that fails, or nil with the workload's namespace and the policy
+// expressions for the remaining checks
+func (e *Engine) screen(
+	ctx context.Context,
+	policy *finopsv1alpha1.EnforcementPolicy,
+	w *workload.Workload,
+) (*EvaluationResult, *corev1.Namespace, *Expressions, error) {
+	rejected := &EvaluationResult{
+		Policy:   policy,
+		Workload: w,
+		Matched:  false,
+	}
+
+	// Check if already paused
+	if isPaused(w) {
+		rejected.Reason = "already paused"
+		return rejected, nil, nil, nil
+	}
+
+	// Check for exclusion annotation
+	if isExcluded(w) {
+		rejected.Reason = "excluded by annotation"
+		return rejected, nil, nil, nil
+	}
+
+	// Check snooze annotation
+	if until := SnoozedUntil(w); time.Now().Before(until) {
+		rejected.Reason = fmt.Sprintf("snoozed until %s", until.Format(time.RFC3339))
+		return rejected, nil, nil, nil
+	}
+
+	// Check workload kind
+	if !scope.MatchesKind(w.Kind, policy.Spec.Scope) {
+		rejected.Reason = "kind not in scope"
+		return rejected, nil, nil, nil
+	}
+
+	// Check action applies to this workload kind
+	if !supportsAction(w.Kind, policy.Spec.Actions.Type) {
+		rejected.Reason = "action not supported for kind"
+		return rejected, nil, nil, nil
+	}
+
+	// Check if suspended outside of FinOps Enforcer
+	if w.Kind.Suspendable() && w.Suspended() {
+		rejected.Reason = "already suspended"
+		return rejected, nil, nil, nil
+	}
+
+	// Check namespace scope
+	if !scope.MatchesNamespaceName(w.GetNamespace(), policy.Spec.Scope.Namespaces) {
+		rejected.Reason = "namespace not in scope"
+		return rejected, nil, nil, nil
+	}
+
+	// Check namespace selector and opt-in/opt-out annotations
+	namespace, err := e.namespaceFor(ctx, w)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if selected, reason := scope.NamespaceSelected(namespace, policy.Spec.Scope); !selected {
+		rejected.Reason = reason
+		return rejected, nil, nil, nil
+	}
+
+	// Check label filters
+	if !scope.MatchesLabels(w.GetLabels(), policy.Spec.Scope.Labels) {
+		rejected.Reason = "labels do not match"
+		return rejected, nil, nil, nil
+	}
+
+	// Check scope expression
+	expressions, err := e.Expressions(policy)
+	if err != nil {
+		rejected.Reason = fmt.Sprintf("invalid expression: %v", err)
+		return rejected, nil, nil, nil
+	}
+	inScope, err := expressions.MatchScope(w, namespace)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("scope expression: %w", err)
+	}
+	if !inScope {
+		rejected.Reason = "scope expression not matched"
+		return rejected, nil, nil, nil
+	}
+
+	return nil, namespace, expressions, nil
+}
 
+// checkUtilization queries utilization and returns a non-empty reason when the
+// workload is above either configured threshold
+func (e *Engine) checkUtilization(
+	ctx context.Context,
+	threshold *finopsv1alpha1.UtilizationThresholdSpec,
+	w *workload.Workload,
+	window time.Duration,
+	result *EvaluationResult,
+) (string, error) {
+	if e.utilization == nil {
+		return "utilization source not configured", nil
+	}
+
+	cpu, memory, err := e.utilization.Utilization(ctx, w, window)
+	if err != nil {
+		return "", fmt.Errorf("failed to query utilization: %w", err)
+	}
+	result.CPUUtilization = &cpu
+	result.MemoryUtilization = &memory
+
+	if threshold.CPU != "" {
+		limit, err := ParsePercentage(threshold.CPU)
+		if err != nil {
+			return "", fmt.Errorf("invalid cpu threshold: %w", err)
+		}
+		if cpu >= limit {
+			return fmt.Sprintf("cpu utilization above threshold (%s%%)", formatFloat(cpu)), nil
+		}
+	}
+
+	if threshold.Memory != "" {
+		limit, err := ParsePercentage(threshold.Memory)
+		if err != nil {
+			return "", fmt.Errorf("invalid memory threshold: %w", err)
+		}
+		if memory >= limit {
+			return fmt.Sprintf("memory utilization above threshold (%s%%)", formatFloat(memory)), nil
+		}
+	}
+
+	return "", nil
+}
 
+// ParsePercentage parses a threshold such as "5%" or "5" into a percentage
+func ParsePercentage(s string) (float64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percentage %q", s)
+	}
 
+	if value < 0 || value > 100 {
+		return 0, fmt.Errorf("percentage %q out of range", s)
+	}
 
+	return value, nil
+}
 
+// supportsAction checks if an action type can be applied to a workload kind
+func supportsAction(kind workload.Kind, action finopsv1alpha1.ActionType) bool {
+	switch action {
+	case finopsv1alpha1.ActionTypeScaleToZero:
+		return kind.Scalable()
+	case finopsv1alpha1.ActionTypeSuspend:
+		return kind.Suspendable()
+	default:
+		return false
+	}
+}
 
+// isIdleLongEnough checks if a workload has been idle for required duration
+func (e *Engine) isIdleLongEnough(obj metav1.Object, idleWindow time.Duration) bool {
+	return time.Since(LastActivity(obj)) >= idleWindow
+}
 
+// LastActivity returns when a workload was last known to be active: the most
+// recent of the finops.io/last-activity and finops.io/last-reactivation
+// annotations and its creation timestamp, so new workloads get a grace period
+// of one idle window before they become eligible
+func LastActivity(obj metav1.Object) time.Time {
+	lastActivity := obj.GetCreationTimestamp().Time
+	for _, key := range []string{"finops.io/last-activity", "finops.io/last-reactivation"} {
+		value := obj.GetAnnotations()[key]
+		if value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			continue
+		}
+
+		if t.After(lastActivity) {
+			lastActivity = t
+		}
+	}
+
+	return lastActivity
+}
 
+// PauseScheduledAt returns the time from finops.io/pause-scheduled-at
+func PauseScheduledAt(obj metav1.Object) (time.Time, bool) {
+	return annotationTime(obj, "finops.io/pause-scheduled-at")
+}
 
+// PauseScheduledInDryRun reports whether finops.io/pause-scheduled-at was
+// stamped by a dry-run warning
+func PauseScheduledInDryRun(obj metav1.Object) bool {
+	return obj.GetAnnotations()["finops.io/pause-scheduled-dry-run"] == "true"
+}
 
+// SnoozedUntil returns the time from finops.io/snooze-until, or the zero time
+func SnoozedUntil(obj metav1.Object) time.Time {
+	t, _ := annotationTime(obj, "finops.io/snooze-until")
+	return t
+}
 
+// annotationTime parses an RFC3339 timestamp annotation
+func annotationTime(obj metav1.Object, key string) (time.Time, bool) {
+	value := obj.GetAnnotations()[key]
+	if value == "" {
+		return time.Time{}, false
+	}
 
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
 
+	return t, true
+}
 
+// ValidateSchedule parses the policy schedule, loads its calendars and
+// parses the reactivation schedule
+func (e *Engine) ValidateSchedule(ctx context.Context, policy *finopsv1alpha1.EnforcementPolicy) error {
+	if policy.Spec.Schedule != nil {
+		if _, _, err := e.isWithinSchedule(ctx, policy.Namespace, policy.Spec.Schedule, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	if policy.Spec.Reactivation != nil {
+		if _, err := ParseReactivation(policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
+// ScheduleActive reports whether the policy schedule is active now and, if it
+// is not, why. Policies without a schedule are always active.
+func (e *Engine) ScheduleActive(ctx context.Context, policy *finopsv1alpha1.EnforcementPolicy) (bool, string, error) {
+	if policy.Spec.Schedule == nil {
+		return true, "", nil
+	}
 
+	return e.isWithinSchedule(ctx, policy.Namespace, policy.Spec.Schedule, time.Now())
+}
 
+// isWithinSchedule checks if a time is within policy schedule and explains
+// why. Blackout calendar entries win over everything else, then active
+// calendar entries, then the schedule windows. An invalid schedule or a
+// calendar that cannot be loaded is reported instead of being treated as
+// always active.
+func (e *Engine) isWithinSchedule(
+	ctx context.Context,
+	namespace string,
+	spec *finopsv1alpha1.ScheduleSpec,
+	now time.Time,
+) (bool, string, error) {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return false, "", err
+	}
+
+	calendarActive := false
+	calendarReason := ""
+	for _, ref := range spec.Calendars {
+		if e.calendars == nil {
+			return false, "", fmt.Errorf("calendar %s referenced but no calendar source configured", ref.Name)
+		}
+
+		calendar, err := e.calendars.Calendar(ctx, namespace, ref.Name)
+		if err != nil {
+			return false, "", err
+		}
+
+		entry, ok := calendar.EntryAt(now, schedule.Location())
+		if !ok {
+			continue
+		}
+
+		if ref.Mode == finopsv1alpha1.CalendarModeBlackout {
+			return false, fmt.Sprintf("blackout calendar %s: %s", ref.Name, entry.Summary), nil
+		}
+		if !calendarActive {
+			calendarActive = true
+			calendarReason = fmt.Sprintf("active calendar %s: %s", ref.Name, entry.Summary)
+		}
+	}
+
+	if calendarActive {
+		return true, calendarReason, nil
+	}
+	if schedule.Active(now) {
+		return true, "", nil
+	}
+	return false, "outside scheduled hours", nil
+}
 
+// isCooldownExpired checks if cooldown period has passed
+func (e *Engine) isCooldownExpired(obj metav1.Object, cooldownWindow time.Duration) bool {
+	pausedAtStr := obj.GetAnnotations()["finops.io/paused-at"]
+	if pausedAtStr == "" {
+		return true // Never paused before
+	}
 
+	pausedAt, err := time.Parse(time.RFC3339, pausedAtStr)
+	if err != nil {
+		return true // Can't parse - assume expired
+	}
 
+	return time.Since(pausedAt) >= cooldownWindow
+}
 
+// isPaused checks if a workload is already paused
+func isPaused(obj metav1.Object) bool {
+	return obj.GetAnnotations()["finops.io/paused"] == "true"
+}
 
+// isExcluded checks if a workload has exclusion annotation
+func isExcluded(obj metav1.Object) bool {
+	return obj.GetAnnotations()["finops.io/exclude"] == "true"
+}
 
+// buildMatchReason constructs a human-readable reason for policy match
+func buildMatchReason(policy *finopsv1alpha1.EnforcementPolicy, result *EvaluationResult) string {
+	traffic := "zero traffic detected"
+	if result.RequestsPerMinute != nil {
+		traffic = formatFloat(*result.RequestsPerMinute) + " req/min observed"
+	}
 
+	reason := "Idle for " + policy.Spec.Conditions.IdleWindow.Duration.String() + ", " + traffic
+	if result.CPUUtilization != nil && result.MemoryUtilization != nil {
+		reason += ", CPU " + formatFloat(*result.CPUUtilization) + "%, memory " +
+			formatFloat(*result.MemoryUtilization) + "% of requests"
+	}
 
+	return reason + ", hourly cost: $" + formatFloat(result.CostData.HourlyCost)
+}
 
-}	return metav1.FormatResourceQuantity(metav1.ResourceList{}, 2)func formatFloat(f float64) string {// formatFloat formats float with 2 decimal places}		", zero traffic detected, hourly cost: $" + formatFloat(costData.HourlyCost)	return "Idle for " + policy.Spec.Conditions.IdleWindow.Duration.String() +func buildMatchReason(policy *finopsv1alpha1.EnforcementPolicy, costData *cost.CostData) string {// buildMatchReason constructs a human-readable reason for policy match}	return matched	matched, _ := filepath.Match(pattern, value)func matchPattern(pattern, value string) bool {// matchPattern performs wildcard pattern matching}	return deployment.Annotations["finops.io/exclude"] == "true"func isExcluded(deployment *appsv1.Deployment) bool {// isExcluded checks if deployment has exclusion annotation}	return deployment.Annotations["finops.io/paused"] == "true"func isPaused(deployment *appsv1.Deployment) bool {// isPaused checks if deployment is already paused}	return time.Since(pausedAt) >= cooldownWindow	}		return true // Can't parse - assume expired	if err != nil {	pausedAt, err := time.Parse(time.RFC3339, pausedAtStr)	}		return true // Never paused before	if pausedAtStr == "" {	pausedAtStr := deployment.Annotations["finops.io/paused-at"]func (e *Engine) isCooldownExpired(deployment *appsv1.Deployment, cooldownWindow time.Duration) bool {// isCooldownExpired checks if cooldown period has passed}	return false	}		}			}				return true			if currentHour >= start && currentHour <= end {			start, end := activeHours.Hours[0], activeHours.Hours[1]		if len(activeHours.Hours) == 2 {		// Check if current hour is within range		}			continue		if !dayMatches {		}			}				break				dayMatches = true			if day == currentDay {		for _, day := range activeHours.Days {		dayMatches := false		// Check if current day matches	for _, activeHours := range schedule.ActiveHours {	currentHour := now.Hour()	currentDay := now.Weekday().String()[:3] // Mon, Tue, etc.	now := time.Now().In(loc)	}		return true		// Invalid timezone - default to allowing	if err != nil {	loc, err := time.LoadLocation(schedule.Timezone)func (e *Engine) isWithinSchedule(schedule *finopsv1alpha1.ScheduleSpec) bool {// isWithinSchedule checks if current time is within policy schedule}	return time.Since(lastActivity) >= idleWindow	}		return true	if err != nil {	lastActivity, err := time.Parse(time.RFC3339, lastActivityStr)	}		return true		// In production, this would integrate with metrics/traffic data		// No activity tracking - assume idle	if lastActivityStr == "" {	lastActivityStr := deployment.Annotations["finops.io/last-activity"]	// Check for last activity annotationfunc (e *Engine) isIdleLongEnough(deployment *appsv1.Deployment, idleWindow time.Duration) bool {// isIdleLongEnough checks if deployment has been idle for required duration}	return true	}		}			return false		if labels[key] != value {	for key, value := range filter.Match {	// Check required matches	}		}			return false		if labels[key] == value {	for key, value := range filter.Exclude {	// Check exclusions firstfunc (e *Engine) matchesLabelFilter(labels map[string]string, filter finopsv1alpha1.LabelFilter) bool {// matchesLabelFilter checks if labels match policy filter}	return false	}		}			return true		if matchPattern(pattern, namespace) {	for _, pattern := range filter.Include {	// Check inclusions	}		}			return false		if matchPattern(pattern, namespace) {	for _, pattern := range filter.Exclude {	// Check exclusions firstfunc (e *Engine) matchesNamespaceScope(namespace string, filter finopsv1alpha1.NamespaceFilter) bool {// matchesNamespaceScope checks if namespace matches policy scope}	return result, nil	}		DryRun:                  policy.Spec.Enforcement.DryRun,		Policy:                  policy.Name,		EstimatedMonthlySavings: cost.EstimateMonthlyCost(costData.HourlyCost),		Reason:                  result.Reason,		OriginalReplicas:        *deployment.Spec.Replicas,		Deployment:              deployment,		Type:                    policy.Spec.Actions.Type,	result.Action = &EnforcementAction{	result.Reason = buildMatchReason(policy, costData)	result.Matched = true	// All conditions matched - create action	}		}			return result, nil			result.Reason = "cooldown not expired"		if !e.isCooldownExpired(deployment, policy.Spec.Enforcement.CooldownWindow.Duration) {	if policy.Spec.Enforcement.CooldownWindow.Duration > 0 {	// Check cooldown	}		}			return result, nil			result.Reason = "outside scheduled hours"		if !e.isWithinSchedule(policy.Spec.Schedule) {	if policy.Spec.Schedule != nil {	// Check schedule (if defined)	}		return result, nil		result.Reason = "not idle long enough"	if !e.isIdleLongEnough(deployment, policy.Spec.Conditions.IdleWindow.Duration) {	// Check idle window	}		return result, nil		result.Reason = "cost below threshold"	if costData.HourlyCost < policy.Spec.Conditions.MinHourlyCost {	// Check cost threshold	}		}			return result, nil			result.Reason = "labels do not match"		if !e.matchesLabelFilter(deployment.Labels, *policy.Spec.Scope.Labels) {	if policy.Spec.Scope.Labels != nil {	// Check label filters	}		return result, nil		result.Reason = "namespace not in scope"	if !e.matchesNamespaceScope(deployment.Namespace, policy.Spec.Scope.Namespaces) {	// Check namespace scope	}		return result, nil		result.Reason = "excluded by annotation"	if isExcluded(deployment) {	// Check for exclusion annotation	}		return result, nil		result.Reason = "already paused"	if isPaused(deployment) {	// Check if already paused	}		Matched:    false,		CostData:   costData,		Deployment: deployment,		Policy:     policy,	result := &EvaluationResult{) (*EvaluationResult, error) {	costData *cost.CostData,	deployment *appsv1.Deployment,	policy *finopsv1alpha1.EnforcementPolicy,	ctx context.Context,func (e *Engine) Evaluate(// Evaluate evaluates a deployment against a policy}	DryRun                   bool	Policy                   string	EstimatedMonthlySavings  float64	Reason                   string	OriginalReplicas         int32	Deployment               *appsv1.Deployment	Type                     finopsv1alpha1.ActionTypetype EnforcementAction struct {// EnforcementAction represents an action to be taken}	Action     *EnforcementAction	Reason     string
\ No newline at end of file
+// formatFloat formats float with 2 decimal places
+func formatFloat(f float64) string {
+	return fmt.Sprintf("%.2f", f)
+}
@@ -1,67 +1,17 @@
 package policy
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/cost"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func TestMatchesNamespaceScope(t *testing.T) {
-	engine := NewEngine()
-
-	tests := []struct {
-		name      string
-		namespace string
-		filter    finopsv1alpha1.NamespaceFilter
-		want      bool
-	}{
-		{
-			name:      "exact match",
-			namespace: "dev-test",
-			filter: finopsv1alpha1.NamespaceFilter{
-				Include: []string{"dev-test"},
-			},
-			want: true,
-		},
-		{
-			name:      "wildcard match",
-			namespace: "dev-feature-xyz",
-			filter: finopsv1alpha1.NamespaceFilter{
-				Include: []string{"dev-*"},
-			},
-			want: true,
-		},
-		{
-			name:      "excluded namespace",
-			namespace: "prod",
-			filter: finopsv1alpha1.NamespaceFilter{
-				Include: []string{"*"},
-				Exclude: []string{"prod"},
-			},
-			want: false,
-		},
-		{
-			name:      "no match",
-			namespace: "staging",
-			filter: finopsv1alpha1.NamespaceFilter{
-				Include: []string{"dev-*"},
-			},
-			want: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := engine.matchesNamespaceScope(tt.namespace, tt.filter)
-			if got != tt.want {
-				t.Errorf("matchesNamespaceScope() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestIsPaused(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -145,24 +95,389 @@ func TestIsExcluded(t *testing.T) {
 	}
 }
 
-func TestMatchPattern(t *testing.T) {
+func TestSupportsAction(t *testing.T) {
 	tests := []struct {
-		pattern string
-		value   string
-		want    bool
+		kind   workload.Kind
+		action finopsv1alpha1.ActionType
+		want   bool
 	}{
-		{pattern: "dev-*", value: "dev-test", want: true},
-		{pattern: "dev-*", value: "prod-test", want: false},
-		{pattern: "*", value: "anything", want: true},
-		{pattern: "exact", value: "exact", want: true},
-		{pattern: "exact", value: "not-exact", want: false},
+		{kind: workload.KindDeployment, action: finopsv1alpha1.ActionTypeScaleToZero, want: true},
+		{kind: workload.KindStatefulSet, action: finopsv1alpha1.ActionTypeScaleToZero, want: true},
+		{kind: workload.KindCronJob, action: finopsv1alpha1.ActionTypeScaleToZero, want: false},
+		{kind: workload.KindCronJob, action: finopsv1alpha1.ActionTypeSuspend, want: true},
+		{kind: workload.KindDeployment, action: finopsv1alpha1.ActionTypeSuspend, want: false},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.pattern+"_"+tt.value, func(t *testing.T) {
-			got := matchPattern(tt.pattern, tt.value)
+		t.Run(string(tt.kind)+"_"+string(tt.action), func(t *testing.T) {
+			got := supportsAction(tt.kind, tt.action)
 			if got != tt.want {
-				t.Errorf("matchPattern(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+				t.Errorf("supportsAction(%q, %q) = %v, want %v", tt.kind, tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeTrafficSource returns a fixed request rate
+type fakeTrafficSource struct {
+	rate float64
+}
+
+func (f fakeTrafficSource) RequestsPerMinute(ctx context.Context, queryTemplate string, w *workload.Workload, window time.Duration) (float64, error) {
+	return f.rate, nil
+}
+
+func TestEvaluateTrafficThreshold(t *testing.T) {
+	replicas := int32(2)
+	newWorkload := func() *workload.Workload {
+		return workload.FromDeployment(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-test"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		})
+	}
+	newPolicy := func() *finopsv1alpha1.EnforcementPolicy {
+		return &finopsv1alpha1.EnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev-idle"},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Scope: finopsv1alpha1.ScopeSpec{
+					Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"dev-*"}},
+				},
+				Conditions: finopsv1alpha1.ConditionsSpec{
+					IdleWindow:       metav1.Duration{Duration: time.Hour},
+					TrafficThreshold: &finopsv1alpha1.TrafficThresholdSpec{RequestsPerMinute: 1},
+				},
+				Actions: finopsv1alpha1.ActionsSpec{Type: finopsv1alpha1.ActionTypeScaleToZero},
+			},
+		}
+	}
+	costData := &cost.CostData{HourlyCost: 1.0}
+
+	tests := []struct {
+		name        string
+		engine      *Engine
+		wantMatched bool
+		wantReason  string
+	}{
+		{
+			name:        "traffic at threshold",
+			engine:      NewEngine(WithTrafficSource(fakeTrafficSource{rate: 1})),
+			wantMatched: true,
+			wantReason:  "Idle for 1h0m0s, 1.00 req/min observed, hourly cost: $1.00",
+		},
+		{
+			name:        "traffic above threshold",
+			engine:      NewEngine(WithTrafficSource(fakeTrafficSource{rate: 12.5})),
+			wantMatched: false,
+			wantReason:  "traffic above threshold (12.50 req/min)",
+		},
+		{
+			name:        "no traffic source",
+			engine:      NewEngine(),
+			wantMatched: false,
+			wantReason:  "traffic source not configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.engine.Evaluate(context.Background(), newPolicy(), newWorkload(), costData)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if result.Matched != tt.wantMatched {
+				t.Errorf("Matched = %v, want %v", result.Matched, tt.wantMatched)
+			}
+			if result.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", result.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+// fakeUtilizationSource returns fixed utilization percentages
+type fakeUtilizationSource struct {
+	cpu    float64
+	memory float64
+}
+
+func (f fakeUtilizationSource) Utilization(ctx context.Context, w *workload.Workload, window time.Duration) (float64, float64, error) {
+	return f.cpu, f.memory, nil
+}
+
+func TestEvaluateUtilizationThreshold(t *testing.T) {
+	replicas := int32(2)
+	newWorkload := func() *workload.Workload {
+		return workload.FromDeployment(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-test"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		})
+	}
+	newPolicy := func(cpu, memory string) *finopsv1alpha1.EnforcementPolicy {
+		return &finopsv1alpha1.EnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev-idle"},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Scope: finopsv1alpha1.ScopeSpec{
+					Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"dev-*"}},
+				},
+				Conditions: finopsv1alpha1.ConditionsSpec{
+					IdleWindow: metav1.Duration{Duration: time.Hour},
+					UtilizationThreshold: &finopsv1alpha1.UtilizationThresholdSpec{
+						CPU:    cpu,
+						Memory: memory,
+					},
+				},
+				Actions: finopsv1alpha1.ActionsSpec{Type: finopsv1alpha1.ActionTypeScaleToZero},
+			},
+		}
+	}
+	costData := &cost.CostData{HourlyCost: 1.0}
+	source := WithUtilizationSource(fakeUtilizationSource{cpu: 2.5, memory: 8})
+
+	tests := []struct {
+		name        string
+		engine      *Engine
+		policy      *finopsv1alpha1.EnforcementPolicy
+		wantMatched bool
+		wantReason  string
+		wantErr     bool
+	}{
+		{
+			name:        "below both thresholds",
+			engine:      NewEngine(source),
+			policy:      newPolicy("5%", "10%"),
+			wantMatched: true,
+			wantReason:  "Idle for 1h0m0s, zero traffic detected, CPU 2.50%, memory 8.00% of requests, hourly cost: $1.00",
+		},
+		{
+			name:        "cpu above threshold",
+			engine:      NewEngine(source),
+			policy:      newPolicy("2%", ""),
+			wantMatched: false,
+			wantReason:  "cpu utilization above threshold (2.50%)",
+		},
+		{
+			name:        "memory above threshold",
+			engine:      NewEngine(source),
+			policy:      newPolicy("5", "8"),
+			wantMatched: false,
+			wantReason:  "memory utilization above threshold (8.00%)",
+		},
+		{
+			name:        "no utilization source",
+			engine:      NewEngine(),
+			policy:      newPolicy("5%", ""),
+			wantMatched: false,
+			wantReason:  "utilization source not configured",
+		},
+		{
+			name:    "invalid threshold",
+			engine:  NewEngine(source),
+			policy:  newPolicy("five", ""),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.engine.Evaluate(context.Background(), tt.policy, newWorkload(), costData)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result.Matched != tt.wantMatched {
+				t.Errorf("Matched = %v, want %v", result.Matched, tt.wantMatched)
+			}
+			if result.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", result.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestLastActivity(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        time.Time
+	}{
+		{
+			name: "new workload uses creation timestamp",
+			want: created,
+		},
+		{
+			name:        "last-activity after creation",
+			annotations: map[string]string{"finops.io/last-activity": "2026-01-03T00:00:00Z"},
+			want:        time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "most recent of activity and reactivation",
+			annotations: map[string]string{
+				"finops.io/last-activity":     "2026-01-03T00:00:00Z",
+				"finops.io/last-reactivation": "2026-01-05T00:00:00Z",
+			},
+			want: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "invalid annotation ignored",
+			annotations: map[string]string{"finops.io/last-activity": "yesterday"},
+			want:        created,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(created),
+					Annotations:       tt.annotations,
+				},
+			}
+
+			if got := LastActivity(obj); !got.Equal(tt.want) {
+				t.Errorf("LastActivity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateWarningPeriod(t *testing.T) {
+	replicas := int32(2)
+	newWorkload := func(annotations map[string]string) *workload.Workload {
+		return workload.FromDeployment(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-test", Annotations: annotations},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		})
+	}
+	newPolicy := func(minHourlyCost float64, dryRun bool) *finopsv1alpha1.EnforcementPolicy {
+		return &finopsv1alpha1.EnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev-idle"},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Scope: finopsv1alpha1.ScopeSpec{
+					Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"dev-*"}},
+				},
+				Conditions: finopsv1alpha1.ConditionsSpec{
+					IdleWindow:    metav1.Duration{Duration: time.Hour},
+					MinHourlyCost: minHourlyCost,
+				},
+				Actions: finopsv1alpha1.ActionsSpec{
+					Type:          finopsv1alpha1.ActionTypeScaleToZero,
+					WarningPeriod: metav1.Duration{Duration: 24 * time.Hour},
+				},
+				Enforcement: finopsv1alpha1.EnforcementSpec{DryRun: &dryRun},
+			},
+		}
+	}
+	costData := &cost.CostData{HourlyCost: 1.0}
+	past := time.Now().Add(-time.Minute).Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		minHourlyCost float64
+		dryRun        bool
+		wantMatched   bool
+		wantWarning   bool
+		wantAction    bool
+		wantScheduled bool
+		wantCancel    bool
+	}{
+		{
+			name:          "first match warns",
+			wantMatched:   true,
+			wantWarning:   true,
+			wantAction:    true,
+			wantScheduled: true,
+		},
+		{
+			name:          "waiting for warning period",
+			annotations:   map[string]string{"finops.io/pause-scheduled-at": future},
+			wantMatched:   true,
+			wantScheduled: true,
+		},
+		{
+			name:        "warning period elapsed",
+			annotations: map[string]string{"finops.io/pause-scheduled-at": past},
+			wantMatched: true,
+			wantAction:  true,
+		},
+		{
+			name:          "no longer idle cancels schedule",
+			annotations:   map[string]string{"finops.io/pause-scheduled-at": future},
+			minHourlyCost: 5.0,
+			wantCancel:    true,
+		},
+		{
+			name: "snoozed cancels schedule",
+			annotations: map[string]string{
+				"finops.io/pause-scheduled-at": past,
+				"finops.io/snooze-until":       future,
+			},
+			wantCancel: true,
+		},
+		{
+			name:          "dry-run first match warns",
+			dryRun:        true,
+			wantMatched:   true,
+			wantWarning:   true,
+			wantAction:    true,
+			wantScheduled: true,
+		},
+		{
+			name: "dry-run waits for warning period",
+			annotations: map[string]string{
+				"finops.io/pause-scheduled-at":      future,
+				"finops.io/pause-scheduled-dry-run": "true",
+			},
+			dryRun:        true,
+			wantMatched:   true,
+			wantScheduled: true,
+		},
+		{
+			name: "enforcing warns again after a dry-run schedule",
+			annotations: map[string]string{
+				"finops.io/pause-scheduled-at":      past,
+				"finops.io/pause-scheduled-dry-run": "true",
+			},
+			wantMatched:   true,
+			wantWarning:   true,
+			wantAction:    true,
+			wantScheduled: true,
+		},
+		{
+			name:          "expired snooze is ignored",
+			annotations:   map[string]string{"finops.io/snooze-until": past},
+			wantMatched:   true,
+			wantWarning:   true,
+			wantAction:    true,
+			wantScheduled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewEngine().Evaluate(context.Background(), newPolicy(tt.minHourlyCost, tt.dryRun), newWorkload(tt.annotations), costData)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if result.Matched != tt.wantMatched {
+				t.Errorf("Matched = %v, want %v (reason %q)", result.Matched, tt.wantMatched, result.Reason)
+			}
+			if (result.Action != nil) != tt.wantAction {
+				t.Fatalf("Action = %v, want action %v", result.Action, tt.wantAction)
+			}
+			if result.Action != nil && result.Action.Warning != tt.wantWarning {
+				t.Errorf("Action.Warning = %v, want %v", result.Action.Warning, tt.wantWarning)
+			}
+			if (result.PauseScheduledAt != nil) != tt.wantScheduled {
+				t.Errorf("PauseScheduledAt = %v, want set %v", result.PauseScheduledAt, tt.wantScheduled)
+			}
+			if result.CancelScheduledPause != tt.wantCancel {
+				t.Errorf("CancelScheduledPause = %v, want %v", result.CancelScheduledPause, tt.wantCancel)
 			}
 		})
 	}
@@ -0,0 +1,310 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/cost"
+	"github.com/yourusername/finops-enforcer/pkg/scope"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Variables available to policy expressions
+const (
+	// ExpressionObject is the workload object, e.g. object.spec.replicas
+	ExpressionObject = "object"
+	// ExpressionNamespace is the workload's Namespace object, e.g.
+	// namespaceObject.metadata.labels; namespace is reserved in CEL
+	ExpressionNamespace = "namespaceObject"
+	// ExpressionCost is the workload's cost data, e.g. cost.hourlyCost; only
+	// available to conditions expressions
+	ExpressionCost = "cost"
+)
+
+// expressionCostLimit bounds the work of a single evaluation so a
+// pathological expression cannot stall a reconcile
+const expressionCostLimit = 1000000
+
+// Expressions are the compiled CEL expressions of a policy, one program per
+// selected workload kind. A nil Expressions, or one without an expression,
+// matches everything.
+type Expressions struct {
+	scope      map[workload.Kind]cel.Program
+	conditions map[workload.Kind]cel.Program
+}
+
+// compiledExpressions caches the expressions of one policy generation
+type compiledExpressions struct {
+	generation  int64
+	expressions *Expressions
+	err         error
+}
+
+// CompileExpressions type-checks and compiles the scope and conditions
+// expressions of a policy spec against each workload kind the policy selects.
+// Scope expressions see the workload object and its namespace; conditions
+// expressions also see its cost data.
+func CompileExpressions(spec *finopsv1alpha1.EnforcementPolicySpec) (*Expressions, field.ErrorList) {
+	var errs field.ErrorList
+	expressions := &Expressions{}
+	kinds := scope.Kinds(spec.Scope)
+
+	if spec.Scope.Expression != "" {
+		path := field.NewPath("spec", "scope", "expression")
+		programs, err := compileForKinds(spec.Scope.Expression, kinds, false)
+		if err != nil {
+			errs = append(errs, field.Invalid(path, spec.Scope.Expression, err.Error()))
+		}
+		expressions.scope = programs
+	}
+
+	if spec.Conditions.Expression != "" {
+		path := field.NewPath("spec", "conditions", "expression")
+		programs, err := compileForKinds(spec.Conditions.Expression, kinds, true)
+		if err != nil {
+			errs = append(errs, field.Invalid(path, spec.Conditions.Expression, err.Error()))
+		}
+		expressions.conditions = programs
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return expressions, nil
+}
+
+// compileForKinds compiles an expression once per workload kind. An error
+// names the kinds it applies to unless it applies to all of them, e.g. a
+// syntax error.
+func compileForKinds(expression string, kinds []workload.Kind, withCost bool) (map[workload.Kind]cel.Program, error) {
+	programs := make(map[workload.Kind]cel.Program, len(kinds))
+	var failed []workload.Kind
+	var messages []string
+	for _, kind := range kinds {
+		objectType, ok := expressionObjectType(kind)
+		if !ok {
+			continue
+		}
+
+		program, err := compileExpression(expression, objectType, withCost)
+		if err != nil {
+			failed = append(failed, kind)
+			messages = append(messages, err.Error())
+			continue
+		}
+		programs[kind] = program
+	}
+
+	if len(failed) == 0 {
+		return programs, nil
+	}
+	if len(failed) == len(kinds) && allEqual(messages) {
+		return nil, errors.New(messages[0])
+	}
+
+	parts := make([]string, len(failed))
+	for i, kind := range failed {
+		parts[i] = fmt.Sprintf("%s: %s", kind, messages[i])
+	}
+	return nil, errors.New(strings.Join(parts, "; "))
+}
+
+// allEqual reports whether all strings are the same
+func allEqual(values []string) bool {
+	for _, v := range values[1:] {
+		if v != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// compileExpression compiles one expression that must evaluate to a bool
+func compileExpression(expression string, objectType *types.Type, withCost bool) (cel.Program, error) {
+	options := []cel.EnvOption{
+		cel.CustomTypeProvider(expressionTypes),
+		cel.Variable(ExpressionObject, objectType),
+		cel.Variable(ExpressionNamespace, expressionNamespaceType()),
+	}
+	if withCost {
+		options = append(options, cel.Variable(ExpressionCost, cel.ObjectType(expressionCostType)))
+	}
+
+	env, err := cel.NewEnv(options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create expression environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if outputType := ast.OutputType(); !outputType.IsExactType(cel.BoolType) && !outputType.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("expression must evaluate to a bool, got %s", outputType)
+	}
+
+	return env.Program(ast, cel.CostLimit(expressionCostLimit))
+}
+
+// HasScope reports whether a scope expression is set
+func (x *Expressions) HasScope() bool {
+	return x != nil && len(x.scope) > 0
+}
+
+// HasConditions reports whether a conditions expression is set
+func (x *Expressions) HasConditions() bool {
+	return x != nil && len(x.conditions) > 0
+}
+
+// MatchScope evaluates the scope expression against a workload
+func (x *Expressions) MatchScope(w *workload.Workload, namespace *corev1.Namespace) (bool, error) {
+	if !x.HasScope() {
+		return true, nil
+	}
+
+	activation, err := expressionActivation(w, namespace, nil)
+	if err != nil {
+		return false, err
+	}
+	return evalBool(x.scope, w.Kind, activation)
+}
+
+// MatchConditions evaluates the conditions expression against a workload and
+// its cost data
+func (x *Expressions) MatchConditions(w *workload.Workload, namespace *corev1.Namespace, costData *cost.CostData) (bool, error) {
+	if !x.HasConditions() {
+		return true, nil
+	}
+
+	activation, err := expressionActivation(w, namespace, costData)
+	if err != nil {
+		return false, err
+	}
+	return evalBool(x.conditions, w.Kind, activation)
+}
+
+// expressionActivation converts the expression inputs to CEL values
+func expressionActivation(
+	w *workload.Workload,
+	namespace *corev1.Namespace,
+	costData *cost.CostData,
+) (map[string]interface{}, error) {
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(w.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert workload: %w", err)
+	}
+	namespaceObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert namespace: %w", err)
+	}
+
+	activation := map[string]interface{}{
+		ExpressionObject:    object,
+		ExpressionNamespace: namespaceObject,
+	}
+	if costData != nil {
+		labels := costData.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		activation[ExpressionCost], err = runtime.DefaultUnstructuredConverter.ToUnstructured(&expressionCost{
+			HourlyCost:  costData.HourlyCost,
+			DailyCost:   costData.DailyCost,
+			TotalCost:   costData.TotalCost,
+			MonthlyCost: cost.EstimateMonthlyCost(costData.HourlyCost),
+			Labels:      labels,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert cost data: %w", err)
+		}
+	}
+	return activation, nil
+}
+
+// evalBool runs the program of a workload kind, which must return a bool
+func evalBool(programs map[workload.Kind]cel.Program, kind workload.Kind, activation map[string]interface{}) (bool, error) {
+	program, ok := programs[kind]
+	if !ok {
+		return false, fmt.Errorf("expression not compiled for kind %s", kind)
+	}
+	value, _, err := program.Eval(activation)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression: %w", err)
+	}
+	matched, ok := value.(types.Bool)
+	if !ok {
+		return false, fmt.Errorf("expression returned %s, want bool", value.Type())
+	}
+	return bool(matched), nil
+}
+
+// Expressions returns the compiled expressions of a policy. They are compiled
+// once per policy generation and reused until the spec changes.
+func (e *Engine) Expressions(policy *finopsv1alpha1.EnforcementPolicy) (*Expressions, error) {
+	key := expressionsKey(policy)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if cached, ok := e.expressions[key]; ok && cached.generation == policy.Generation {
+		return cached.expressions, cached.err
+	}
+
+	expressions, errs := CompileExpressions(&policy.Spec)
+	compiled := compiledExpressions{generation: policy.Generation, expressions: expressions}
+	if len(errs) > 0 {
+		compiled.err = errs.ToAggregate()
+	}
+	e.expressions[key] = compiled
+	return compiled.expressions, compiled.err
+}
+
+// RetainExpressions drops the compiled expressions of every policy not in
+// policies, e.g. deleted ones
+func (e *Engine) RetainExpressions(policies []*finopsv1alpha1.EnforcementPolicy) {
+	live := make(map[string]bool, len(policies))
+	for _, policy := range policies {
+		live[expressionsKey(policy)] = true
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for key := range e.expressions {
+		if !live[key] {
+			delete(e.expressions, key)
+		}
+	}
+}
+
+// expressionsKey identifies a policy in the compiled expressions cache
+func expressionsKey(policy *finopsv1alpha1.EnforcementPolicy) string {
+	if policy.UID != "" {
+		return string(policy.UID)
+	}
+	return policy.Namespace + "/" + policy.Name
+}
+
+// namespaceFor returns the Namespace object of a workload. Without a
+// namespace reader only its name is known.
+func (e *Engine) namespaceFor(ctx context.Context, w *workload.Workload) (*corev1.Namespace, error) {
+	if e.namespaces == nil {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: w.GetNamespace()}}, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := e.namespaces.Get(ctx, client.ObjectKey{Name: w.GetNamespace()}, namespace); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", w.GetNamespace(), err)
+	}
+	return namespace, nil
+}
@@ -0,0 +1,344 @@
+package policy
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/cost"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCompileExpressions(t *testing.T) {
+	tests := []struct {
+		name        string
+		kinds       []finopsv1alpha1.WorkloadKind
+		scope       string
+		conditions  string
+		want        []string
+		wantMessage string
+	}{
+		{name: "none"},
+		{
+			name:       "valid",
+			scope:      `object.spec.replicas > 1 && has(object.metadata.labels.owner)`,
+			conditions: `cost.monthlyCost > 100.0`,
+		},
+		{
+			name:  "valid for every kind",
+			kinds: []finopsv1alpha1.WorkloadKind{finopsv1alpha1.WorkloadKindDeployment, finopsv1alpha1.WorkloadKindCronJob},
+			scope: `object.metadata.annotations["team"] == "payments" && namespaceObject.metadata.labels.env == "dev"`,
+		},
+		{
+			name:        "misspelled field",
+			scope:       `object.spec.replica > 1`,
+			want:        []string{"spec.scope.expression"},
+			wantMessage: "undefined field 'replica'",
+		},
+		{
+			name:        "ill-typed field",
+			scope:       `object.spec.replicas == "3"`,
+			want:        []string{"spec.scope.expression"},
+			wantMessage: "no matching overload",
+		},
+		{
+			name:        "field of another kind",
+			kinds:       []finopsv1alpha1.WorkloadKind{finopsv1alpha1.WorkloadKindDeployment, finopsv1alpha1.WorkloadKindCronJob},
+			scope:       `object.spec.suspend == false`,
+			want:        []string{"spec.scope.expression"},
+			wantMessage: "Deployment: ",
+		},
+		{
+			name:        "misspelled namespace field",
+			scope:       `namespaceObject.metadata.label.env == "dev"`,
+			want:        []string{"spec.scope.expression"},
+			wantMessage: "undefined field 'label'",
+		},
+		{
+			name:        "misspelled cost field",
+			conditions:  `cost.monthly > 100.0`,
+			want:        []string{"spec.conditions.expression"},
+			wantMessage: "undefined field 'monthly'",
+		},
+		{
+			name:  "syntax error",
+			scope: `object.spec.replicas >`,
+			want:  []string{"spec.scope.expression"},
+		},
+		{
+			name:  "cost is not available to scope",
+			scope: `cost.hourlyCost > 1.0`,
+			want:  []string{"spec.scope.expression"},
+		},
+		{
+			name:       "not a bool",
+			conditions: `cost.hourlyCost * 2.0`,
+			want:       []string{"spec.conditions.expression"},
+		},
+		{
+			name:       "both invalid",
+			scope:      `undeclared == 1`,
+			conditions: `"idle"`,
+			want:       []string{"spec.scope.expression", "spec.conditions.expression"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &finopsv1alpha1.EnforcementPolicySpec{
+				Scope:      finopsv1alpha1.ScopeSpec{Kinds: tt.kinds, Expression: tt.scope},
+				Conditions: finopsv1alpha1.ConditionsSpec{Expression: tt.conditions},
+			}
+
+			var got []string
+			_, errs := CompileExpressions(spec)
+			for _, err := range errs {
+				got = append(got, err.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompileExpressions() fields = %v, want %v", got, tt.want)
+			}
+			if tt.wantMessage != "" && !strings.Contains(errs.ToAggregate().Error(), tt.wantMessage) {
+				t.Errorf("CompileExpressions() errors = %v, want %q", errs, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestExpressionsMatch(t *testing.T) {
+	replicas := int32(3)
+	w := workload.FromDeployment(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-a", Labels: map[string]string{"owner": "payments"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "api", Image: "registry.example.com/api:1.4.2"}},
+			}},
+		},
+	})
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "dev-a",
+		Labels: map[string]string{"env": "dev"},
+	}}
+	costData := &cost.CostData{HourlyCost: 0.5}
+
+	tests := []struct {
+		name       string
+		scope      string
+		conditions string
+		wantScope  bool
+		wantIdle   bool
+		wantErr    bool
+	}{
+		{
+			name:      "no expressions",
+			wantScope: true,
+			wantIdle:  true,
+		},
+		{
+			name: "replicas, image tag and owner label",
+			scope: `object.spec.replicas > 1 &&
+				object.spec.template.spec.containers.all(c, !c.image.endsWith(":latest")) &&
+				has(object.metadata.labels.owner)`,
+			wantScope: true,
+			wantIdle:  true,
+		},
+		{
+			name:      "namespace labels",
+			scope:     `namespaceObject.metadata.labels.env == "prod"`,
+			wantScope: false,
+			wantIdle:  true,
+		},
+		{
+			name:       "monthly cost",
+			conditions: `cost.monthlyCost < 500.0 && namespaceObject.metadata.labels.env == "dev"`,
+			wantScope:  true,
+			wantIdle:   true,
+		},
+		{
+			name:       "cost above limit",
+			conditions: `cost.hourlyCost < 0.1`,
+			wantScope:  true,
+			wantIdle:   false,
+		},
+		{
+			name:    "missing field",
+			scope:   `object.spec.paused`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expressions, errs := CompileExpressions(&finopsv1alpha1.EnforcementPolicySpec{
+				Scope:      finopsv1alpha1.ScopeSpec{Expression: tt.scope},
+				Conditions: finopsv1alpha1.ConditionsSpec{Expression: tt.conditions},
+			})
+			if len(errs) > 0 {
+				t.Fatalf("CompileExpressions() errors = %v", errs)
+			}
+
+			inScope, err := expressions.MatchScope(w, namespace)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MatchScope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if inScope != tt.wantScope {
+				t.Errorf("MatchScope() = %v, want %v", inScope, tt.wantScope)
+			}
+
+			idle, err := expressions.MatchConditions(w, namespace, costData)
+			if err != nil {
+				t.Fatalf("MatchConditions() error = %v", err)
+			}
+			if idle != tt.wantIdle {
+				t.Errorf("MatchConditions() = %v, want %v", idle, tt.wantIdle)
+			}
+		})
+	}
+}
+
+func TestExpressionsMatchPerKind(t *testing.T) {
+	expressions, errs := CompileExpressions(&finopsv1alpha1.EnforcementPolicySpec{
+		Scope: finopsv1alpha1.ScopeSpec{
+			Kinds:      []finopsv1alpha1.WorkloadKind{finopsv1alpha1.WorkloadKindDeployment, finopsv1alpha1.WorkloadKindCronJob},
+			Expression: `object.metadata.name.startsWith("batch-")`,
+		},
+	})
+	if len(errs) > 0 {
+		t.Fatalf("CompileExpressions() errors = %v", errs)
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-a"}}
+	cronJob := workload.FromCronJob(&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "batch-report", Namespace: "dev-a"}})
+	if inScope, err := expressions.MatchScope(cronJob, namespace); err != nil || !inScope {
+		t.Errorf("MatchScope(CronJob) = %v, %v, want true", inScope, err)
+	}
+
+	// Kinds the policy does not select have no program
+	statefulSet := workload.FromStatefulSet(&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "batch-db", Namespace: "dev-a"}})
+	if _, err := expressions.MatchScope(statefulSet, namespace); err == nil {
+		t.Error("MatchScope(StatefulSet) expected error for a kind outside the policy")
+	}
+}
+
+func TestEngineExpressionsCompiledPerGeneration(t *testing.T) {
+	engine := NewEngine()
+	p := validPolicy()
+	p.UID = "uid-1"
+	p.Generation = 1
+	p.Spec.Scope.Expression = `object.spec.replicas > 1`
+
+	first, err := engine.Expressions(p)
+	if err != nil {
+		t.Fatalf("Expressions() error = %v", err)
+	}
+	again, _ := engine.Expressions(p)
+	if first != again {
+		t.Error("Expressions() recompiled an unchanged generation")
+	}
+
+	p.Generation = 2
+	p.Spec.Scope.Expression = `object.spec.replicas >`
+	if _, err := engine.Expressions(p); err == nil {
+		t.Error("Expressions() = nil error, want the new generation's compile error")
+	}
+}
+
+func TestEngineRetainExpressions(t *testing.T) {
+	engine := NewEngine()
+	kept, deleted := validPolicy(), validPolicy()
+	kept.UID, deleted.UID = "uid-kept", "uid-deleted"
+
+	for _, p := range []*finopsv1alpha1.EnforcementPolicy{kept, deleted} {
+		if _, err := engine.Expressions(p); err != nil {
+			t.Fatalf("Expressions() error = %v", err)
+		}
+	}
+
+	engine.RetainExpressions([]*finopsv1alpha1.EnforcementPolicy{kept})
+
+	if _, ok := engine.expressions["uid-kept"]; !ok {
+		t.Error("expressions of a live policy were dropped")
+	}
+	if _, ok := engine.expressions["uid-deleted"]; ok {
+		t.Error("expressions of a deleted policy were kept")
+	}
+}
+
+func TestEvaluateExpressions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	namespaces := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev-test", Labels: map[string]string{"finops.io/tier": "sandbox"}},
+	}).Build()
+
+	replicas := int32(2)
+	w := workload.FromDeployment(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-test"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	})
+	costData := &cost.CostData{HourlyCost: 1.0}
+
+	tests := []struct {
+		name        string
+		scope       string
+		conditions  string
+		wantMatched bool
+		wantReason  string
+	}{
+		{
+			name:        "both expressions hold",
+			scope:       `namespaceObject.metadata.labels["finops.io/tier"] == "sandbox"`,
+			conditions:  `cost.hourlyCost >= 1.0`,
+			wantMatched: true,
+		},
+		{
+			name:       "scope expression false",
+			scope:      `object.spec.replicas > 5`,
+			wantReason: "scope expression not matched",
+		},
+		{
+			name:       "conditions expression false",
+			conditions: `cost.dailyCost > 0.0`,
+			wantReason: "conditions expression not matched",
+		},
+		{
+			name:       "invalid expression",
+			scope:      `object.spec.replicas >`,
+			wantReason: "invalid expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := validPolicy()
+			p.Spec.Conditions.IdleWindow = metav1.Duration{Duration: time.Hour}
+			p.Spec.Scope.Expression = tt.scope
+			p.Spec.Conditions.Expression = tt.conditions
+
+			result, err := NewEngine(WithNamespaceReader(namespaces)).Evaluate(context.Background(), p, w, costData)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if result.Matched != tt.wantMatched {
+				t.Errorf("Matched = %v (%s), want %v", result.Matched, result.Reason, tt.wantMatched)
+			}
+			if !strings.HasPrefix(result.Reason, tt.wantReason) {
+				t.Errorf("Reason = %q, want prefix %q", result.Reason, tt.wantReason)
+			}
+		})
+	}
+}
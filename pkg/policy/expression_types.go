@@ -0,0 +1,207 @@
+package policy
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// expressionCostType is the CEL type name of the cost variable
+const expressionCostType = "finops.io.Cost"
+
+// expressionCost is the cost data seen by conditions expressions
+type expressionCost struct {
+	HourlyCost  float64           `json:"hourlyCost"`
+	DailyCost   float64           `json:"dailyCost"`
+	TotalCost   float64           `json:"totalCost"`
+	MonthlyCost float64           `json:"monthlyCost"`
+	Labels      map[string]string `json:"labels"`
+}
+
+// expressionKinds maps each workload kind to the Go type its object variable
+// is declared from
+var expressionKinds = map[workload.Kind]reflect.Type{
+	workload.KindDeployment:  reflect.TypeOf(appsv1.Deployment{}),
+	workload.KindStatefulSet: reflect.TypeOf(appsv1.StatefulSet{}),
+	workload.KindCronJob:     reflect.TypeOf(batchv1.CronJob{}),
+}
+
+// expressionTypes declares the object, namespaceObject and cost variables to
+// the CEL type checker, so unknown or ill-typed fields are rejected when a
+// policy is compiled
+var expressionTypes = newObjectTypes()
+
+var (
+	jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// objectTypes is a CEL type provider for Kubernetes objects. Struct types
+// and their fields are derived from the Go API types by their JSON names,
+// matching the unstructured maps expressions are evaluated against. Values
+// are never read through the provider, so fields carry no accessors.
+type objectTypes struct {
+	types.Provider
+	structs map[string]map[string]*types.FieldType
+}
+
+// newObjectTypes declares the types of every supported workload kind, the
+// Namespace and the cost data
+func newObjectTypes() *objectTypes {
+	base, err := types.NewRegistry()
+	if err != nil {
+		panic(fmt.Sprintf("failed to create CEL type registry: %v", err))
+	}
+
+	p := &objectTypes{Provider: base, structs: map[string]map[string]*types.FieldType{}}
+	for _, t := range expressionKinds {
+		p.declare(t)
+	}
+	p.declare(reflect.TypeOf(corev1.Namespace{}))
+	p.declareStruct(expressionCostType, reflect.TypeOf(expressionCost{}))
+
+	return p
+}
+
+// expressionObjectType returns the declared CEL type of a workload kind
+func expressionObjectType(kind workload.Kind) (*types.Type, bool) {
+	t, ok := expressionKinds[kind]
+	if !ok {
+		return nil, false
+	}
+	return types.NewObjectType(objectTypeName(t)), true
+}
+
+// expressionNamespaceType returns the declared CEL type of a Namespace
+func expressionNamespaceType() *types.Type {
+	return types.NewObjectType(objectTypeName(reflect.TypeOf(corev1.Namespace{})))
+}
+
+// FindStructType returns a declared object type, or defers to the base registry
+func (p *objectTypes) FindStructType(structType string) (*types.Type, bool) {
+	if _, ok := p.structs[structType]; ok {
+		return types.NewTypeTypeWithParam(types.NewObjectType(structType)), true
+	}
+	return p.Provider.FindStructType(structType)
+}
+
+// FindStructFieldType returns a field of a declared object type, or defers to
+// the base registry
+func (p *objectTypes) FindStructFieldType(structType, fieldName string) (*types.FieldType, bool) {
+	if fields, ok := p.structs[structType]; ok {
+		field, found := fields[fieldName]
+		return field, found
+	}
+	return p.Provider.FindStructFieldType(structType, fieldName)
+}
+
+// declare returns the CEL type of a Go type, declaring struct types on the way
+func (p *objectTypes) declare(t reflect.Type) *types.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// Types with their own JSON form (Time, Quantity, IntOrString, ...) may
+	// convert to a string, a number or null
+	if t.Implements(jsonMarshaler) || reflect.PointerTo(t).Implements(jsonMarshaler) ||
+		t.Implements(textMarshaler) || reflect.PointerTo(t).Implements(textMarshaler) {
+		return types.DynType
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return types.BoolType
+	case reflect.String:
+		return types.StringType
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// The unstructured converter stores every integer as an int64
+		return types.IntType
+	case reflect.Float32, reflect.Float64:
+		return types.DoubleType
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are base64 strings in JSON
+			return types.StringType
+		}
+		return types.NewListType(p.declare(t.Elem()))
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return types.DynType
+		}
+		return types.NewMapType(types.StringType, p.declare(t.Elem()))
+	case reflect.Struct:
+		name := objectTypeName(t)
+		p.declareStruct(name, t)
+		return types.NewObjectType(name)
+	default:
+		return types.DynType
+	}
+}
+
+// declareStruct declares a struct type and the types of its JSON fields
+func (p *objectTypes) declareStruct(name string, t reflect.Type) {
+	if _, ok := p.structs[name]; ok {
+		return
+	}
+
+	// Registered before the fields are walked so recursive types terminate
+	fields := map[string]*types.FieldType{}
+	p.structs[name] = fields
+	p.declareFields(fields, t)
+}
+
+// declareFields adds the JSON fields of a struct, flattening inlined ones
+func (p *objectTypes) declareFields(fields map[string]*types.FieldType, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if (f.Anonymous && name == "") || strings.Contains(opts, "inline") {
+			inlined := f.Type
+			for inlined.Kind() == reflect.Pointer {
+				inlined = inlined.Elem()
+			}
+			if inlined.Kind() == reflect.Struct {
+				p.declareFields(fields, inlined)
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fields[name] = &types.FieldType{Type: p.declare(f.Type)}
+	}
+}
+
+// objectTypeName names a Go type the way the Kubernetes OpenAPI schema does,
+// e.g. io.k8s.api.apps.v1.Deployment
+func objectTypeName(t reflect.Type) string {
+	host, path, _ := strings.Cut(t.PkgPath(), "/")
+	domain := strings.Split(host, ".")
+	for i, j := 0, len(domain)-1; i < j; i, j = i+1, j-1 {
+		domain[i], domain[j] = domain[j], domain[i]
+	}
+
+	name := strings.Join(domain, ".")
+	if path != "" {
+		name += "." + strings.ReplaceAll(path, "/", ".")
+	}
+	return name + "." + t.Name()
+}
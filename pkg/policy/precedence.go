@@ -0,0 +1,114 @@
+package policy
+
+import (
+	"sort"
+	"strings"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/scope"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Policy kinds competing for enforcement decisions
+const (
+	KindEnforcementPolicy        = "EnforcementPolicy"
+	KindClusterEnforcementPolicy = "ClusterEnforcementPolicy"
+)
+
+// Precedence tiers, strongest first
+const (
+	// tierNamespaceOverride is an EnforcementPolicy acting in its own namespace
+	tierNamespaceOverride = iota
+	// tierCluster is a ClusterEnforcementPolicy
+	tierCluster
+)
+
+// Candidate is a policy competing to own enforcement decisions
+type Candidate struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Priority  int32
+	Scope     finopsv1alpha1.ScopeSpec
+}
+
+// Ref identifies the candidate as ClusterEnforcementPolicy/name or
+// EnforcementPolicy/namespace/name
+func (c Candidate) Ref() string {
+	if c.Kind == KindClusterEnforcementPolicy {
+		return c.Kind + "/" + c.Name
+	}
+	return c.Kind + "/" + c.Namespace + "/" + c.Name
+}
+
+// MatchesRef reports whether ref, e.g. a finops.io/policy annotation, names
+// the candidate. Annotations written before refs were stored hold a bare
+// EnforcementPolicy name.
+func (c Candidate) MatchesRef(ref string) bool {
+	if ref == c.Ref() {
+		return true
+	}
+	return c.Kind == KindEnforcementPolicy && !strings.Contains(ref, "/") && ref == c.Name
+}
+
+// ParseRef parses a Ref into the kind, namespace and name of a policy. It
+// returns false for anything else, including a bare policy name.
+func ParseRef(ref string) (Candidate, bool) {
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) == 2 && parts[0] == KindClusterEnforcementPolicy && parts[1] != "":
+		return Candidate{Kind: parts[0], Name: parts[1]}, true
+	case len(parts) == 3 && parts[0] == KindEnforcementPolicy && parts[1] != "" && parts[2] != "":
+		return Candidate{Kind: parts[0], Namespace: parts[1], Name: parts[2]}, true
+	default:
+		return Candidate{}, false
+	}
+}
+
+// Reaches reports whether the candidate may act in namespace. An
+// EnforcementPolicy only acts in its own namespace, whatever its scope.
+func (c Candidate) Reaches(namespace string) bool {
+	return c.Kind == KindClusterEnforcementPolicy || c.Namespace == namespace
+}
+
+// tier ranks how strongly the candidate claims workloads
+func (c Candidate) tier() int {
+	if c.Kind == KindClusterEnforcementPolicy {
+		return tierCluster
+	}
+	return tierNamespaceOverride
+}
+
+// Owner resolves which candidate owns the enforcement decision for a
+// workload. An EnforcementPolicy in the workload's namespace wins over a
+// ClusterEnforcementPolicy; within a tier the highest priority wins and ties
+// are broken by Ref. Ownership depends only on scope, so it does not change with schedules
+// or cost. It also returns the other matching candidates, strongest first,
+// and false when no candidate matches.
+func Owner(candidates []Candidate, w *workload.Workload, namespace *corev1.Namespace) (Candidate, []Candidate, bool) {
+	matching := []Candidate{}
+	for _, c := range candidates {
+		if !c.Reaches(w.GetNamespace()) {
+			continue
+		}
+		if inScope, _ := scope.Matches(c.Scope, w, namespace); inScope {
+			matching = append(matching, c)
+		}
+	}
+	if len(matching) == 0 {
+		return Candidate{}, nil, false
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		if ti, tj := matching[i].tier(), matching[j].tier(); ti != tj {
+			return ti < tj
+		}
+		if matching[i].Priority != matching[j].Priority {
+			return matching[i].Priority > matching[j].Priority
+		}
+		return matching[i].Ref() < matching[j].Ref()
+	})
+
+	return matching[0], matching[1:], true
+}
@@ -0,0 +1,328 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOwner(t *testing.T) {
+	scope := func(include ...string) finopsv1alpha1.ScopeSpec {
+		return finopsv1alpha1.ScopeSpec{Namespaces: finopsv1alpha1.NamespaceFilter{Include: include}}
+	}
+	cluster := func(name string, priority int32, scope finopsv1alpha1.ScopeSpec) Candidate {
+		return Candidate{Kind: KindClusterEnforcementPolicy, Name: name, Priority: priority, Scope: scope}
+	}
+	namespaced := func(namespace, name string, priority int32, scope finopsv1alpha1.ScopeSpec) Candidate {
+		return Candidate{Kind: KindEnforcementPolicy, Namespace: namespace, Name: name, Priority: priority, Scope: scope}
+	}
+	deployment := func(namespace string, labels map[string]string) *workload.Workload {
+		return workload.FromDeployment(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace, Labels: labels},
+		})
+	}
+
+	teamScope := scope("dev-*")
+	teamScope.Labels = &finopsv1alpha1.LabelFilter{Match: map[string]string{"team": "payments"}}
+	statefulSetScope := scope("*")
+	statefulSetScope.Kinds = []finopsv1alpha1.WorkloadKind{finopsv1alpha1.WorkloadKindStatefulSet}
+	selectorScope := scope("*")
+	selectorScope.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}
+
+	tests := []struct {
+		name       string
+		candidates []Candidate
+		workload   *workload.Workload
+		namespace  *corev1.Namespace
+		wantOwner  string
+		wantOthers []string
+	}{
+		{
+			name:       "no match",
+			candidates: []Candidate{cluster("prod", 0, scope("prod-*"))},
+			workload:   deployment("dev-a", nil),
+		},
+		{
+			name:       "single cluster policy",
+			candidates: []Candidate{cluster("defaults", 0, scope("*"))},
+			workload:   deployment("dev-a", nil),
+			wantOwner:  "ClusterEnforcementPolicy/defaults",
+		},
+		{
+			name: "namespace override wins over cluster policy",
+			candidates: []Candidate{
+				cluster("defaults", 0, scope("*")),
+				namespaced("dev-a", "team-a", 0, scope("dev-a")),
+			},
+			workload:   deployment("dev-a", nil),
+			wantOwner:  "EnforcementPolicy/dev-a/team-a",
+			wantOthers: []string{"ClusterEnforcementPolicy/defaults"},
+		},
+		{
+			name: "ties broken by name",
+			candidates: []Candidate{
+				cluster("b-defaults", 0, scope("dev-*")),
+				cluster("a-defaults", 0, scope("*")),
+			},
+			workload:   deployment("dev-a", nil),
+			wantOwner:  "ClusterEnforcementPolicy/a-defaults",
+			wantOthers: []string{"ClusterEnforcementPolicy/b-defaults"},
+		},
+		{
+			name: "override only applies in its own namespace",
+			candidates: []Candidate{
+				cluster("defaults", 0, scope("*")),
+				namespaced("dev-a", "team-a", 0, scope("dev-*")),
+			},
+			workload:  deployment("dev-b", nil),
+			wantOwner: "ClusterEnforcementPolicy/defaults",
+		},
+		{
+			name:       "policy in another namespace never matches",
+			candidates: []Candidate{namespaced("finops-system", "dev-idle", 1000, scope("dev-*"))},
+			workload:   deployment("dev-a", nil),
+		},
+		{
+			name: "higher priority wins within a tier",
+			candidates: []Candidate{
+				namespaced("dev-a", "dev-idle-gc", 0, scope("dev-*")),
+				namespaced("dev-a", "high-cost-idle", 10, scope("*")),
+			},
+			workload:   deployment("dev-a", nil),
+			wantOwner:  "EnforcementPolicy/dev-a/high-cost-idle",
+			wantOthers: []string{"EnforcementPolicy/dev-a/dev-idle-gc"},
+		},
+		{
+			name: "negative priority loses to the default",
+			candidates: []Candidate{
+				cluster("a-fallback", -1, scope("*")),
+				cluster("b-defaults", 0, scope("*")),
+			},
+			workload:   deployment("dev-a", nil),
+			wantOwner:  "ClusterEnforcementPolicy/b-defaults",
+			wantOthers: []string{"ClusterEnforcementPolicy/a-fallback"},
+		},
+		{
+			name: "priority does not cross tiers",
+			candidates: []Candidate{
+				cluster("defaults", 100, scope("*")),
+				namespaced("dev-a", "team-a", 0, scope("dev-a")),
+			},
+			workload:   deployment("dev-a", nil),
+			wantOwner:  "EnforcementPolicy/dev-a/team-a",
+			wantOthers: []string{"ClusterEnforcementPolicy/defaults"},
+		},
+		{
+			name: "others ordered by precedence",
+			candidates: []Candidate{
+				namespaced("dev-a", "dev-idle", 5, scope("dev-*")),
+				cluster("low", 1, scope("*")),
+				cluster("high", 2, scope("*")),
+				namespaced("dev-a", "cleanup", 5, scope("dev-*")),
+			},
+			workload:  deployment("dev-a", nil),
+			wantOwner: "EnforcementPolicy/dev-a/cleanup",
+			wantOthers: []string{
+				"EnforcementPolicy/dev-a/dev-idle",
+				"ClusterEnforcementPolicy/high",
+				"ClusterEnforcementPolicy/low",
+			},
+		},
+		{
+			name: "excluded namespace does not compete",
+			candidates: []Candidate{
+				cluster("defaults", 0, finopsv1alpha1.ScopeSpec{Namespaces: finopsv1alpha1.NamespaceFilter{
+					Include: []string{"*"},
+					Exclude: []string{"dev-a"},
+				}}),
+				namespaced("dev-a", "dev-idle", 0, scope("dev-*")),
+			},
+			workload:  deployment("dev-a", nil),
+			wantOwner: "EnforcementPolicy/dev-a/dev-idle",
+		},
+		{
+			name: "label filter narrows the competing policies",
+			candidates: []Candidate{
+				cluster("payments", 10, teamScope),
+				cluster("defaults", 0, scope("*")),
+			},
+			workload:  deployment("dev-a", map[string]string{"team": "search"}),
+			wantOwner: "ClusterEnforcementPolicy/defaults",
+		},
+		{
+			name: "label filter match wins on priority",
+			candidates: []Candidate{
+				cluster("payments", 10, teamScope),
+				cluster("defaults", 0, scope("*")),
+			},
+			workload:   deployment("dev-a", map[string]string{"team": "payments"}),
+			wantOwner:  "ClusterEnforcementPolicy/payments",
+			wantOthers: []string{"ClusterEnforcementPolicy/defaults"},
+		},
+		{
+			name: "kind filter narrows the competing policies",
+			candidates: []Candidate{
+				cluster("statefulsets", 10, statefulSetScope),
+				cluster("defaults", 0, scope("*")),
+			},
+			workload:  deployment("dev-a", nil),
+			wantOwner: "ClusterEnforcementPolicy/defaults",
+		},
+		{
+			name: "namespace selector narrows the competing policies",
+			candidates: []Candidate{
+				cluster("dev-env", 10, selectorScope),
+				cluster("defaults", 0, scope("*")),
+			},
+			workload: deployment("dev-a", nil),
+			namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "dev-a",
+				Labels: map[string]string{"env": "staging"},
+			}},
+			wantOwner: "ClusterEnforcementPolicy/defaults",
+		},
+		{
+			name: "namespace selector match wins on priority",
+			candidates: []Candidate{
+				cluster("dev-env", 10, selectorScope),
+				cluster("defaults", 0, scope("*")),
+			},
+			workload: deployment("dev-a", nil),
+			namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "dev-a",
+				Labels: map[string]string{"env": "dev"},
+			}},
+			wantOwner:  "ClusterEnforcementPolicy/dev-env",
+			wantOthers: []string{"ClusterEnforcementPolicy/defaults"},
+		},
+		{
+			name:       "opted-out namespace has no owner",
+			candidates: []Candidate{cluster("defaults", 0, scope("*"))},
+			workload:   deployment("dev-a", nil),
+			namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "dev-a",
+				Annotations: map[string]string{"finops.io/exclude": "true"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace := tt.namespace
+			if namespace == nil {
+				namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: tt.workload.GetNamespace()}}
+			}
+
+			owner, others, ok := Owner(tt.candidates, tt.workload, namespace)
+			if ok != (tt.wantOwner != "") {
+				t.Fatalf("Owner() ok = %v, want owner %q", ok, tt.wantOwner)
+			}
+			if ok && owner.Ref() != tt.wantOwner {
+				t.Errorf("Owner() = %s, want %s", owner.Ref(), tt.wantOwner)
+			}
+
+			var got []string
+			for _, other := range others {
+				got = append(got, other.Ref())
+			}
+			if !reflect.DeepEqual(got, tt.wantOthers) {
+				t.Errorf("Owner() others = %v, want %v", got, tt.wantOthers)
+			}
+		})
+	}
+}
+
+func TestOwnerIsOrderIndependent(t *testing.T) {
+	candidates := []Candidate{
+		{Kind: KindEnforcementPolicy, Namespace: "dev-a", Name: "dev-idle-gc", Scope: finopsv1alpha1.ScopeSpec{
+			Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"dev-*"}},
+		}},
+		{Kind: KindEnforcementPolicy, Namespace: "dev-a", Name: "high-cost-idle", Scope: finopsv1alpha1.ScopeSpec{
+			Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"*"}},
+		}},
+		{Kind: KindClusterEnforcementPolicy, Name: "defaults", Priority: -5, Scope: finopsv1alpha1.ScopeSpec{
+			Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"*"}},
+		}},
+	}
+	w := workload.FromDeployment(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-a"},
+	})
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-a"}}
+
+	// Every reconcile lists policies in its own order, but they must all
+	// agree on the owner
+	for i := range candidates {
+		rotated := append(append([]Candidate{}, candidates[i:]...), candidates[:i]...)
+		owner, _, _ := Owner(rotated, w, namespace)
+		if owner.Ref() != "EnforcementPolicy/dev-a/dev-idle-gc" {
+			t.Errorf("rotation %d: Owner() = %s, want EnforcementPolicy/dev-a/dev-idle-gc", i, owner.Ref())
+		}
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		ref    string
+		want   Candidate
+		wantOK bool
+	}{
+		{
+			ref:    "EnforcementPolicy/finops-system/dev-idle",
+			want:   Candidate{Kind: KindEnforcementPolicy, Namespace: "finops-system", Name: "dev-idle"},
+			wantOK: true,
+		},
+		{
+			ref:    "ClusterEnforcementPolicy/defaults",
+			want:   Candidate{Kind: KindClusterEnforcementPolicy, Name: "defaults"},
+			wantOK: true,
+		},
+		{ref: "dev-idle"},
+		{ref: "EnforcementPolicy/dev-idle"},
+		{ref: "ClusterEnforcementPolicy/finops-system/defaults"},
+		{ref: "Deployment/dev-a/api"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, ok := ParseRef(tt.ref)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRef() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+			if ok && got.Ref() != tt.ref {
+				t.Errorf("Ref() = %s, want %s", got.Ref(), tt.ref)
+			}
+		})
+	}
+}
+
+func TestCandidateMatchesRef(t *testing.T) {
+	namespaced := Candidate{Kind: KindEnforcementPolicy, Namespace: "finops-system", Name: "weekend"}
+	cluster := Candidate{Kind: KindClusterEnforcementPolicy, Name: "weekend"}
+
+	tests := []struct {
+		name      string
+		candidate Candidate
+		ref       string
+		want      bool
+	}{
+		{name: "own ref", candidate: namespaced, ref: "EnforcementPolicy/finops-system/weekend", want: true},
+		{name: "same name in another namespace", candidate: namespaced, ref: "EnforcementPolicy/team-a/weekend"},
+		{name: "cluster policy of the same name", candidate: namespaced, ref: "ClusterEnforcementPolicy/weekend"},
+		{name: "bare name", candidate: namespaced, ref: "weekend", want: true},
+		{name: "cluster own ref", candidate: cluster, ref: "ClusterEnforcementPolicy/weekend", want: true},
+		{name: "cluster ignores bare name", candidate: cluster, ref: "weekend"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.candidate.MatchesRef(tt.ref); got != tt.want {
+				t.Errorf("MatchesRef(%q) = %v, want %v", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
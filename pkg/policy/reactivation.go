@@ -0,0 +1,164 @@
+package policy
+
+import (
+	"fmt"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultStaggerInterval is the delay between consecutive scheduled restores
+const DefaultStaggerInterval = 10 * time.Second
+
+// Reactivation is a parsed policy reactivation spec
+type Reactivation struct {
+	location *time.Location
+	times    []wakeTime
+	ttl      time.Duration
+
+	// Stagger is the delay between consecutive restores
+	Stagger time.Duration
+}
+
+// wakeTime is a wake-up time in minutes since midnight on the given days
+type wakeTime struct {
+	days   map[time.Weekday]bool
+	minute int
+}
+
+// ParseReactivation validates the reactivation spec of a policy. Times are
+// interpreted in the reactivation timezone, falling back to the schedule
+// timezone and then UTC.
+func ParseReactivation(policy *finopsv1alpha1.EnforcementPolicy) (*Reactivation, error) {
+	spec := policy.Spec.Reactivation
+
+	timezone := spec.Timezone
+	if timezone == "" && policy.Spec.Schedule != nil {
+		timezone = policy.Spec.Schedule.Timezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reactivation timezone %q: %w", timezone, err)
+	}
+
+	if len(spec.Schedule) == 0 && spec.TTL.Duration <= 0 {
+		return nil, fmt.Errorf("reactivation requires a schedule or a positive ttl")
+	}
+
+	r := &Reactivation{
+		location: loc,
+		ttl:      spec.TTL.Duration,
+		Stagger:  spec.StaggerInterval.Duration,
+	}
+	if r.Stagger <= 0 {
+		r.Stagger = DefaultStaggerInterval
+	}
+
+	for i, t := range spec.Schedule {
+		days, err := parseDays(t.Days)
+		if err != nil {
+			return nil, fmt.Errorf("reactivation schedule[%d]: %w", i, err)
+		}
+		minute, err := parseClock(t.At)
+		if err != nil || minute >= minutesPerDay {
+			return nil, fmt.Errorf("reactivation schedule[%d]: invalid time %q, expected HH:MM", i, t.At)
+		}
+		r.times = append(r.times, wakeTime{days: days, minute: minute})
+	}
+
+	return r, nil
+}
+
+// Due reports whether a workload paused at pausedAt should be restored at now:
+// its TTL has expired, or a scheduled wake-up time passed since it was paused
+func (r *Reactivation) Due(pausedAt, now time.Time) bool {
+	if r.ttl > 0 && !now.Before(pausedAt.Add(r.ttl)) {
+		return true
+	}
+
+	last, ok := r.lastWakeUp(now)
+	return ok && last.After(pausedAt)
+}
+
+// NextDue returns when a workload paused at pausedAt that is not yet due at
+// now becomes due: its TTL expiry or the next scheduled wake-up time,
+// whichever is earlier
+func (r *Reactivation) NextDue(pausedAt, now time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	if r.ttl > 0 {
+		next, found = pausedAt.Add(r.ttl), true
+	}
+
+	after := now
+	if pausedAt.After(after) {
+		after = pausedAt
+	}
+	if wake, ok := r.nextWakeUp(after); ok && (!found || wake.Before(next)) {
+		next, found = wake, true
+	}
+
+	return next, found
+}
+
+// nextWakeUp returns the first scheduled wake-up time after now
+func (r *Reactivation) nextWakeUp(now time.Time) (time.Time, bool) {
+	local := now.In(r.location)
+
+	var next time.Time
+	found := false
+	for daysAhead := 0; daysAhead <= 7; daysAhead++ {
+		day := local.AddDate(0, 0, daysAhead)
+		for _, t := range r.times {
+			if !t.days[day.Weekday()] {
+				continue
+			}
+			at := time.Date(day.Year(), day.Month(), day.Day(), t.minute/60, t.minute%60, 0, 0, r.location)
+			if !at.After(now) {
+				continue
+			}
+			if !found || at.Before(next) {
+				next, found = at, true
+			}
+		}
+	}
+
+	return next, found
+}
+
+// lastWakeUp returns the most recent scheduled wake-up time at or before now
+func (r *Reactivation) lastWakeUp(now time.Time) (time.Time, bool) {
+	local := now.In(r.location)
+
+	var last time.Time
+	found := false
+	for daysAgo := 0; daysAgo <= 7; daysAgo++ {
+		day := local.AddDate(0, 0, -daysAgo)
+		for _, t := range r.times {
+			if !t.days[day.Weekday()] {
+				continue
+			}
+			at := time.Date(day.Year(), day.Month(), day.Day(), t.minute/60, t.minute%60, 0, 0, r.location)
+			if at.After(now) {
+				continue
+			}
+			if !found || at.After(last) {
+				last, found = at, true
+			}
+		}
+	}
+
+	return last, found
+}
+
+// PausedAt returns the time from finops.io/paused-at
+func PausedAt(obj metav1.Object) (time.Time, bool) {
+	return annotationTime(obj, "finops.io/paused-at")
+}
+
+// ReactivateAt returns the time from finops.io/reactivate-at, the staggered
+// slot of a pending scheduled reactivation
+func ReactivateAt(obj metav1.Object) (time.Time, bool) {
+	return annotationTime(obj, "finops.io/reactivate-at")
+}
@@ -0,0 +1,112 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReactivationDue(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	// 2026-01-05 is a Monday
+	mondayWake := time.Date(2026, 1, 5, 7, 0, 0, 0, newYork)
+	saturday := time.Date(2026, 1, 3, 12, 0, 0, 0, newYork)
+
+	scheduled := &finopsv1alpha1.EnforcementPolicy{Spec: finopsv1alpha1.EnforcementPolicySpec{
+		Schedule: &finopsv1alpha1.ScheduleSpec{Timezone: "America/New_York"},
+		Reactivation: &finopsv1alpha1.ReactivationSpec{
+			Schedule: []finopsv1alpha1.ReactivationTime{{Days: []string{"Mon"}, At: "07:00"}},
+		},
+	}}
+	ttl := &finopsv1alpha1.EnforcementPolicy{Spec: finopsv1alpha1.EnforcementPolicySpec{
+		Reactivation: &finopsv1alpha1.ReactivationSpec{TTL: metav1.Duration{Duration: 8 * time.Hour}},
+	}}
+
+	tests := []struct {
+		name     string
+		policy   *finopsv1alpha1.EnforcementPolicy
+		pausedAt time.Time
+		now      time.Time
+		want     bool
+	}{
+		{name: "before wake-up", policy: scheduled, pausedAt: saturday, now: mondayWake.Add(-time.Minute), want: false},
+		{name: "at wake-up", policy: scheduled, pausedAt: saturday, now: mondayWake, want: true},
+		{name: "missed wake-up still due", policy: scheduled, pausedAt: saturday, now: mondayWake.Add(26 * time.Hour), want: true},
+		{name: "paused after wake-up", policy: scheduled, pausedAt: mondayWake.Add(time.Hour), now: mondayWake.Add(2 * time.Hour), want: false},
+		{name: "ttl not expired", policy: ttl, pausedAt: saturday, now: saturday.Add(7 * time.Hour), want: false},
+		{name: "ttl expired", policy: ttl, pausedAt: saturday, now: saturday.Add(8 * time.Hour), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reactivation, err := ParseReactivation(tt.policy)
+			if err != nil {
+				t.Fatalf("ParseReactivation() error = %v", err)
+			}
+			if got := reactivation.Due(tt.pausedAt, tt.now); got != tt.want {
+				t.Errorf("Due() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReactivationNextDue(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	// 2026-01-05 is a Monday
+	mondayWake := time.Date(2026, 1, 5, 7, 0, 0, 0, newYork)
+	saturday := time.Date(2026, 1, 3, 12, 0, 0, 0, newYork)
+
+	policy := &finopsv1alpha1.EnforcementPolicy{Spec: finopsv1alpha1.EnforcementPolicySpec{
+		Reactivation: &finopsv1alpha1.ReactivationSpec{
+			Timezone: "America/New_York",
+			Schedule: []finopsv1alpha1.ReactivationTime{{Days: []string{"Mon"}, At: "07:00"}},
+			TTL:      metav1.Duration{Duration: 48 * time.Hour},
+		},
+	}}
+	reactivation, err := ParseReactivation(policy)
+	if err != nil {
+		t.Fatalf("ParseReactivation() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		pausedAt time.Time
+		now      time.Time
+		want     time.Time
+	}{
+		{name: "schedule before ttl", pausedAt: saturday, now: saturday.Add(time.Hour), want: mondayWake},
+		{name: "ttl before schedule", pausedAt: mondayWake.Add(time.Hour), now: mondayWake.Add(2 * time.Hour), want: mondayWake.Add(49 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := reactivation.NextDue(tt.pausedAt, tt.now)
+			if !ok || !got.Equal(tt.want) {
+				t.Errorf("NextDue() = %v, %v, want %v", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseReactivationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec finopsv1alpha1.ReactivationSpec
+	}{
+		{name: "empty", spec: finopsv1alpha1.ReactivationSpec{}},
+		{name: "bad timezone", spec: finopsv1alpha1.ReactivationSpec{Timezone: "Nowhere/Else", TTL: metav1.Duration{Duration: time.Hour}}},
+		{name: "bad day", spec: finopsv1alpha1.ReactivationSpec{Schedule: []finopsv1alpha1.ReactivationTime{{Days: []string{"Monday"}, At: "07:00"}}}},
+		{name: "bad time", spec: finopsv1alpha1.ReactivationSpec{Schedule: []finopsv1alpha1.ReactivationTime{{At: "24:00"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &finopsv1alpha1.EnforcementPolicy{Spec: finopsv1alpha1.EnforcementPolicySpec{Reactivation: &tt.spec}}
+			if _, err := ParseReactivation(policy); err == nil {
+				t.Error("ParseReactivation() error = nil, want error")
+			}
+		})
+	}
+}
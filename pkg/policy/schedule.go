@@ -0,0 +1,206 @@
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+)
+
+const minutesPerDay = 24 * 60
+
+// weekdays maps schedule day names to time.Weekday
+var weekdays = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// Schedule is a parsed policy schedule
+type Schedule struct {
+	location *time.Location
+	windows  []window
+}
+
+// Location returns the timezone the schedule is interpreted in
+func (s *Schedule) Location() *time.Location {
+	return s.location
+}
+
+// window is an active period in minutes since midnight; a window whose end is
+// not after its start runs past midnight into the following day
+type window struct {
+	days  map[time.Weekday]bool
+	start int
+	end   int
+}
+
+// ParseSchedule validates a schedule spec and converts it into a Schedule
+func ParseSchedule(spec *finopsv1alpha1.ScheduleSpec) (*Schedule, error) {
+	loc, err := time.LoadLocation(spec.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", spec.Timezone, err)
+	}
+
+	if len(spec.ActiveHours) == 0 && len(spec.Windows) == 0 && len(spec.Calendars) == 0 {
+		return nil, fmt.Errorf("at least one of activeHours, windows or calendars is required")
+	}
+
+	for i, ref := range spec.Calendars {
+		if ref.Name == "" {
+			return nil, fmt.Errorf("calendars[%d]: name is required", i)
+		}
+		if ref.Mode != finopsv1alpha1.CalendarModeActive && ref.Mode != finopsv1alpha1.CalendarModeBlackout {
+			return nil, fmt.Errorf("calendars[%d]: invalid mode %q, expected active or blackout", i, ref.Mode)
+		}
+	}
+
+	s := &Schedule{location: loc}
+
+	for i, activeHours := range spec.ActiveHours {
+		w, err := parseActiveHours(activeHours)
+		if err != nil {
+			return nil, fmt.Errorf("activeHours[%d]: %w", i, err)
+		}
+		s.windows = append(s.windows, w)
+	}
+
+	for i, sw := range spec.Windows {
+		w, err := parseWindow(sw)
+		if err != nil {
+			return nil, fmt.Errorf("windows[%d]: %w", i, err)
+		}
+		s.windows = append(s.windows, w)
+	}
+
+	return s, nil
+}
+
+// Active reports whether t falls within any window of the schedule
+func (s *Schedule) Active(t time.Time) bool {
+	local := t.In(s.location)
+	minute := local.Hour()*60 + local.Minute()
+	today := local.Weekday()
+	yesterday := local.AddDate(0, 0, -1).Weekday()
+
+	for _, w := range s.windows {
+		if w.start < w.end {
+			if w.days[today] && minute >= w.start && minute < w.end {
+				return true
+			}
+			continue
+		}
+
+		// Overnight windows belong to the day they start on
+		if w.days[today] && minute >= w.start {
+			return true
+		}
+		if w.days[yesterday] && minute < w.end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseActiveHours converts a legacy [start, end] hour pair, where the end
+// hour is inclusive
+func parseActiveHours(spec finopsv1alpha1.ActiveHoursSpec) (window, error) {
+	days, err := parseDays(spec.Days)
+	if err != nil {
+		return window{}, err
+	}
+
+	if len(spec.Hours) != 2 {
+		return window{}, fmt.Errorf("hours must be a [start, end] pair, got %v", spec.Hours)
+	}
+
+	start, end := spec.Hours[0], spec.Hours[1]
+	for _, hour := range spec.Hours {
+		if hour < 0 || hour > 23 {
+			return window{}, fmt.Errorf("hour %d out of range 0-23", hour)
+		}
+	}
+
+	return window{
+		days:  days,
+		start: start * 60,
+		end:   ((end + 1) * 60) % minutesPerDay,
+	}, nil
+}
+
+// parseWindow converts an HH:MM window; the end time is exclusive
+func parseWindow(spec finopsv1alpha1.ScheduleWindow) (window, error) {
+	days, err := parseDays(spec.Days)
+	if err != nil {
+		return window{}, err
+	}
+
+	start, err := parseClock(spec.Start)
+	if err != nil {
+		return window{}, fmt.Errorf("start: %w", err)
+	}
+
+	end, err := parseClock(spec.End)
+	if err != nil {
+		return window{}, fmt.Errorf("end: %w", err)
+	}
+
+	if start == end {
+		return window{}, fmt.Errorf("start and end must differ, got %s", spec.Start)
+	}
+
+	return window{days: days, start: start, end: end % minutesPerDay}, nil
+}
+
+// parseDays converts day names into a set; no days means every day
+func parseDays(names []string) (map[time.Weekday]bool, error) {
+	days := make(map[time.Weekday]bool, 7)
+	if len(names) == 0 {
+		for _, day := range weekdays {
+			days[day] = true
+		}
+		return days, nil
+	}
+
+	for _, name := range names {
+		day, ok := weekdays[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid day %q, expected one of Mon, Tue, Wed, Thu, Fri, Sat, Sun", name)
+		}
+		days[day] = true
+	}
+
+	return days, nil
+}
+
+// parseClock converts HH:MM into minutes since midnight; 24:00 is accepted
+// as the end of the day
+func parseClock(value string) (int, error) {
+	hours, minutes, ok := strings.Cut(value, ":")
+	if !ok || len(minutes) != 2 {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+
+	h, err := strconv.Atoi(hours)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+
+	total := h*60 + m
+	if h < 0 || m < 0 || m > 59 || total > minutesPerDay {
+		return 0, fmt.Errorf("time %q out of range 00:00-24:00", value)
+	}
+
+	return total, nil
+}
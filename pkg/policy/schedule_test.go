@@ -0,0 +1,257 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+)
+
+func TestScheduleActive(t *testing.T) {
+	// 2026-01-05 is a Monday
+	at := func(day int, hour, minute int) time.Time {
+		return time.Date(2026, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		spec finopsv1alpha1.ScheduleSpec
+		now  time.Time
+		want bool
+	}{
+		{
+			name: "legacy hours inclusive end",
+			spec: finopsv1alpha1.ScheduleSpec{
+				ActiveHours: []finopsv1alpha1.ActiveHoursSpec{{Days: []string{"Mon"}, Hours: []int{9, 17}}},
+			},
+			now:  at(5, 17, 59),
+			want: true,
+		},
+		{
+			name: "legacy hours outside",
+			spec: finopsv1alpha1.ScheduleSpec{
+				ActiveHours: []finopsv1alpha1.ActiveHoursSpec{{Days: []string{"Mon"}, Hours: []int{9, 17}}},
+			},
+			now:  at(5, 18, 0),
+			want: false,
+		},
+		{
+			name: "legacy all day",
+			spec: finopsv1alpha1.ScheduleSpec{
+				ActiveHours: []finopsv1alpha1.ActiveHoursSpec{{Days: []string{"Sat", "Sun"}, Hours: []int{0, 23}}},
+			},
+			now:  at(4, 23, 30),
+			want: true,
+		},
+		{
+			name: "minute resolution start",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Windows: []finopsv1alpha1.ScheduleWindow{{Start: "18:30", End: "19:00"}},
+			},
+			now:  at(5, 18, 29),
+			want: false,
+		},
+		{
+			name: "minute resolution end is exclusive",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Windows: []finopsv1alpha1.ScheduleWindow{{Start: "18:30", End: "19:00"}},
+			},
+			now:  at(5, 19, 0),
+			want: false,
+		},
+		{
+			name: "overnight before midnight",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Windows: []finopsv1alpha1.ScheduleWindow{{Days: []string{"Fri"}, Start: "22:00", End: "06:00"}},
+			},
+			now:  at(9, 23, 0),
+			want: true,
+		},
+		{
+			name: "overnight after midnight belongs to start day",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Windows: []finopsv1alpha1.ScheduleWindow{{Days: []string{"Fri"}, Start: "22:00", End: "06:00"}},
+			},
+			now:  at(10, 5, 59),
+			want: true,
+		},
+		{
+			name: "overnight after end",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Windows: []finopsv1alpha1.ScheduleWindow{{Days: []string{"Fri"}, Start: "22:00", End: "06:00"}},
+			},
+			now:  at(10, 6, 0),
+			want: false,
+		},
+		{
+			name: "overnight not started on other day",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Windows: []finopsv1alpha1.ScheduleWindow{{Days: []string{"Fri"}, Start: "22:00", End: "06:00"}},
+			},
+			now:  at(9, 3, 0),
+			want: false,
+		},
+		{
+			name: "until midnight",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Windows: []finopsv1alpha1.ScheduleWindow{{Start: "20:00", End: "24:00"}},
+			},
+			now:  at(5, 23, 59),
+			want: true,
+		},
+		{
+			name: "timezone applied",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Timezone: "America/New_York",
+				Windows:  []finopsv1alpha1.ScheduleWindow{{Start: "22:00", End: "06:00"}},
+			},
+			now:  at(6, 2, 0), // 21:00 in New York
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(&tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSchedule() error = %v", err)
+			}
+			if got := schedule.Active(tt.now); got != tt.want {
+				t.Errorf("Active(%s) = %v, want %v", tt.now.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec finopsv1alpha1.ScheduleSpec
+	}{
+		{
+			name: "unknown timezone",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Timezone: "Mars/Olympus_Mons",
+				Windows:  []finopsv1alpha1.ScheduleWindow{{Start: "09:00", End: "17:00"}},
+			},
+		},
+		{
+			name: "no windows",
+			spec: finopsv1alpha1.ScheduleSpec{Timezone: "UTC"},
+		},
+		{
+			name: "hour out of range",
+			spec: finopsv1alpha1.ScheduleSpec{
+				ActiveHours: []finopsv1alpha1.ActiveHoursSpec{{Days: []string{"Mon"}, Hours: []int{9, 24}}},
+			},
+		},
+		{
+			name: "single hour",
+			spec: finopsv1alpha1.ScheduleSpec{
+				ActiveHours: []finopsv1alpha1.ActiveHoursSpec{{Days: []string{"Mon"}, Hours: []int{9}}},
+			},
+		},
+		{
+			name: "unknown day",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Windows: []finopsv1alpha1.ScheduleWindow{{Days: []string{"Monday"}, Start: "09:00", End: "17:00"}},
+			},
+		},
+		{
+			name: "malformed time",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Windows: []finopsv1alpha1.ScheduleWindow{{Start: "9am", End: "17:00"}},
+			},
+		},
+		{
+			name: "minutes out of range",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Windows: []finopsv1alpha1.ScheduleWindow{{Start: "09:60", End: "17:00"}},
+			},
+		},
+		{
+			name: "empty window",
+			spec: finopsv1alpha1.ScheduleSpec{
+				Windows: []finopsv1alpha1.ScheduleWindow{{Start: "09:00", End: "09:00"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSchedule(&tt.spec); err == nil {
+				t.Error("ParseSchedule() error = nil, want error")
+			}
+		})
+	}
+}
+
+type fakeCalendars map[string]*Calendar
+
+func (f fakeCalendars) Calendar(ctx context.Context, namespace, name string) (*Calendar, error) {
+	calendar, ok := f[name]
+	if !ok {
+		return nil, fmt.Errorf("calendar %s not found", name)
+	}
+	return calendar, nil
+}
+
+func TestIsWithinScheduleCalendars(t *testing.T) {
+	engine := NewEngine(WithCalendarSource(fakeCalendars{
+		"holidays": {Name: "holidays", Entries: []CalendarEntry{{
+			Summary:  "Christmas Day",
+			Start:    time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC),
+			Floating: true,
+		}}},
+		"freeze": {Name: "freeze", Entries: []CalendarEntry{{
+			Summary:  "Year-end freeze",
+			Start:    time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2026, 12, 25, 12, 0, 0, 0, time.UTC),
+			Floating: true,
+		}}},
+	}))
+
+	// Weekends only, plus holidays, never during the freeze
+	spec := &finopsv1alpha1.ScheduleSpec{
+		Timezone: "UTC",
+		Windows:  []finopsv1alpha1.ScheduleWindow{{Days: []string{"Sat", "Sun"}, Start: "00:00", End: "24:00"}},
+		Calendars: []finopsv1alpha1.CalendarRef{
+			{Name: "holidays", Mode: finopsv1alpha1.CalendarModeActive},
+			{Name: "freeze", Mode: finopsv1alpha1.CalendarModeBlackout},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		wantActive bool
+		wantReason string
+	}{
+		{name: "weekday", now: time.Date(2026, 12, 22, 12, 0, 0, 0, time.UTC), wantReason: "outside scheduled hours"},
+		{name: "weekend", now: time.Date(2026, 12, 19, 12, 0, 0, 0, time.UTC), wantActive: true},
+		{name: "holiday", now: time.Date(2026, 12, 25, 18, 0, 0, 0, time.UTC), wantActive: true, wantReason: "active calendar holidays: Christmas Day"},
+		{name: "blackout wins over holiday", now: time.Date(2026, 12, 25, 9, 0, 0, 0, time.UTC), wantReason: "blackout calendar freeze: Year-end freeze"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active, reason, err := engine.isWithinSchedule(context.Background(), "finops-system", spec, tt.now)
+			if err != nil {
+				t.Fatalf("isWithinSchedule() error = %v", err)
+			}
+			if active != tt.wantActive || reason != tt.wantReason {
+				t.Errorf("isWithinSchedule() = %v, %q, want %v, %q", active, reason, tt.wantActive, tt.wantReason)
+			}
+		})
+	}
+
+	missing := &finopsv1alpha1.ScheduleSpec{
+		Calendars: []finopsv1alpha1.CalendarRef{{Name: "missing", Mode: finopsv1alpha1.CalendarModeBlackout}},
+	}
+	if _, _, err := engine.isWithinSchedule(context.Background(), "finops-system", missing, time.Now()); err == nil {
+		t.Error("isWithinSchedule() with missing calendar error = nil, want error")
+	}
+}
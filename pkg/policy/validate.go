@@ -0,0 +1,125 @@
+package policy
+
+import (
+	"fmt"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/scope"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultProtectedNamespaces are system namespaces a policy should not target
+var DefaultProtectedNamespaces = []string{"kube-system", "kube-public", "kube-node-lease"}
+
+// Default fills in defaults for a new policy: dry-run unless set otherwise
+func Default(spec *finopsv1alpha1.EnforcementPolicySpec) {
+	if spec.Enforcement.DryRun == nil {
+		dryRun := true
+		spec.Enforcement.DryRun = &dryRun
+	}
+}
+
+// ValidateSpec checks a policy spec for values the engine cannot enforce.
+// Calendars are not loaded, so a missing calendar ConfigMap is not an error.
+func ValidateSpec(policy *finopsv1alpha1.EnforcementPolicy) field.ErrorList {
+	var errs field.ErrorList
+	spec := policy.Spec
+	specPath := field.NewPath("spec")
+
+	scopePath := specPath.Child("scope")
+	errs = append(errs, scope.Validate(spec.Scope, scopePath)...)
+
+	for i, kind := range spec.Scope.Kinds {
+		if _, err := workload.ParseKind(string(kind)); err != nil {
+			errs = append(errs, field.NotSupported(scopePath.Child("kinds").Index(i), kind,
+				[]string{string(workload.KindDeployment), string(workload.KindStatefulSet), string(workload.KindCronJob)}))
+		}
+	}
+	supported := false
+	for _, kind := range scope.Kinds(spec.Scope) {
+		if supportsAction(kind, spec.Actions.Type) {
+			supported = true
+		}
+	}
+	if !supported {
+		errs = append(errs, field.Invalid(specPath.Child("actions", "type"), spec.Actions.Type,
+			fmt.Sprintf("action cannot be applied to any of the selected kinds %v", scope.Kinds(spec.Scope))))
+	}
+
+	conditionsPath := specPath.Child("conditions")
+	if spec.Conditions.IdleWindow.Duration <= 0 {
+		errs = append(errs, field.Invalid(conditionsPath.Child("idleWindow"), spec.Conditions.IdleWindow.Duration.String(), "must be positive"))
+	}
+	if spec.Conditions.MinHourlyCost < 0 {
+		errs = append(errs, field.Invalid(conditionsPath.Child("minHourlyCost"), spec.Conditions.MinHourlyCost, "must not be negative"))
+	}
+	if threshold := spec.Conditions.TrafficThreshold; threshold != nil && threshold.RequestsPerMinute < 0 {
+		errs = append(errs, field.Invalid(conditionsPath.Child("trafficThreshold", "requestsPerMinute"), threshold.RequestsPerMinute, "must not be negative"))
+	}
+	if threshold := spec.Conditions.UtilizationThreshold; threshold != nil {
+		utilizationPath := conditionsPath.Child("utilizationThreshold")
+		if threshold.CPU != "" {
+			if _, err := ParsePercentage(threshold.CPU); err != nil {
+				errs = append(errs, field.Invalid(utilizationPath.Child("cpu"), threshold.CPU, "must be a percentage between 0 and 100, e.g. \"5%\""))
+			}
+		}
+		if threshold.Memory != "" {
+			if _, err := ParsePercentage(threshold.Memory); err != nil {
+				errs = append(errs, field.Invalid(utilizationPath.Child("memory"), threshold.Memory, "must be a percentage between 0 and 100, e.g. \"20%\""))
+			}
+		}
+	}
+
+	actionsPath := specPath.Child("actions")
+	if spec.Actions.WarningPeriod.Duration < 0 {
+		errs = append(errs, field.Invalid(actionsPath.Child("warningPeriod"), spec.Actions.WarningPeriod.Duration.String(), "must not be negative"))
+	}
+
+	enforcementPath := specPath.Child("enforcement")
+	if spec.Enforcement.MaxActionsPerRun < 0 {
+		errs = append(errs, field.Invalid(enforcementPath.Child("maxActionsPerRun"), spec.Enforcement.MaxActionsPerRun, "must not be negative"))
+	}
+	if spec.Enforcement.CooldownWindow.Duration < 0 {
+		errs = append(errs, field.Invalid(enforcementPath.Child("cooldownWindow"), spec.Enforcement.CooldownWindow.Duration.String(), "must not be negative"))
+	}
+
+	if spec.Schedule != nil {
+		if _, err := ParseSchedule(spec.Schedule); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("schedule"), field.OmitValueType{}, err.Error()))
+		}
+	}
+
+	if spec.Reactivation != nil {
+		if _, err := ParseReactivation(policy); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("reactivation"), field.OmitValueType{}, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+// OwnNamespaceWarnings explains that an EnforcementPolicy whose scope does not
+// match its own namespace, the only one it acts in, enforces nothing
+func OwnNamespaceWarnings(policy *finopsv1alpha1.EnforcementPolicy) []string {
+	if scope.MatchesNamespaceName(policy.Namespace, policy.Spec.Scope.Namespaces) {
+		return nil
+	}
+	return []string{fmt.Sprintf(
+		"spec.scope.namespaces does not match %q; an EnforcementPolicy only acts in its own namespace, use a ClusterEnforcementPolicy for other namespaces",
+		policy.Namespace)}
+}
+
+// ProtectedNamespaceWarnings explains which protected namespaces the policy
+// scope would enforce in
+func ProtectedNamespaceWarnings(policy *finopsv1alpha1.EnforcementPolicy, protected []string) []string {
+	var warnings []string
+	for _, namespace := range protected {
+		if scope.MatchesNamespaceName(namespace, policy.Spec.Scope.Namespaces) {
+			warnings = append(warnings, fmt.Sprintf(
+				"spec.scope.namespaces matches protected namespace %q; add it to spec.scope.namespaces.exclude", namespace))
+		}
+	}
+
+	return warnings
+}
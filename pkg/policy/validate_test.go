@@ -0,0 +1,156 @@
+package policy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func validPolicy() *finopsv1alpha1.EnforcementPolicy {
+	return &finopsv1alpha1.EnforcementPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev-idle", Namespace: "finops-system"},
+		Spec: finopsv1alpha1.EnforcementPolicySpec{
+			Scope: finopsv1alpha1.ScopeSpec{
+				Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"dev-*"}},
+			},
+			Conditions: finopsv1alpha1.ConditionsSpec{
+				IdleWindow:    metav1.Duration{Duration: 24 * time.Hour},
+				MinHourlyCost: 0.5,
+			},
+			Actions: finopsv1alpha1.ActionsSpec{
+				Type:   finopsv1alpha1.ActionTypeScaleToZero,
+				Notify: finopsv1alpha1.NotifyTypeNone,
+			},
+		},
+	}
+}
+
+func TestValidateSpec(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*finopsv1alpha1.EnforcementPolicy)
+		want   []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*finopsv1alpha1.EnforcementPolicy) {},
+		},
+		{
+			name: "invalid timezone",
+			mutate: func(p *finopsv1alpha1.EnforcementPolicy) {
+				p.Spec.Schedule = &finopsv1alpha1.ScheduleSpec{
+					Timezone:    "Mars/Olympus_Mons",
+					ActiveHours: []finopsv1alpha1.ActiveHoursSpec{{Hours: []int{9, 17}}},
+				}
+			},
+			want: []string{"spec.schedule"},
+		},
+		{
+			name: "single hour",
+			mutate: func(p *finopsv1alpha1.EnforcementPolicy) {
+				p.Spec.Schedule = &finopsv1alpha1.ScheduleSpec{
+					Timezone:    "UTC",
+					ActiveHours: []finopsv1alpha1.ActiveHoursSpec{{Hours: []int{9}}},
+				}
+			},
+			want: []string{"spec.schedule"},
+		},
+		{
+			name:   "negative min hourly cost",
+			mutate: func(p *finopsv1alpha1.EnforcementPolicy) { p.Spec.Conditions.MinHourlyCost = -1 },
+			want:   []string{"spec.conditions.minHourlyCost"},
+		},
+		{
+			name: "invalid cpu threshold",
+			mutate: func(p *finopsv1alpha1.EnforcementPolicy) {
+				p.Spec.Conditions.UtilizationThreshold = &finopsv1alpha1.UtilizationThresholdSpec{CPU: "abc", Memory: "150%"}
+			},
+			want: []string{"spec.conditions.utilizationThreshold.cpu", "spec.conditions.utilizationThreshold.memory"},
+		},
+		{
+			name:   "action unsupported by kinds",
+			mutate: func(p *finopsv1alpha1.EnforcementPolicy) { p.Spec.Actions.Type = finopsv1alpha1.ActionTypeSuspend },
+			want:   []string{"spec.actions.type"},
+		},
+		{
+			name: "invalid namespace selector",
+			mutate: func(p *finopsv1alpha1.EnforcementPolicy) {
+				p.Spec.Scope.NamespaceSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "env", Operator: metav1.LabelSelectorOpIn},
+				}}
+			},
+			want: []string{"spec.scope.namespaceSelector.matchExpressions[0].values"},
+		},
+		{
+			name: "invalid label expression",
+			mutate: func(p *finopsv1alpha1.EnforcementPolicy) {
+				p.Spec.Scope.Labels = &finopsv1alpha1.LabelFilter{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"payments"}},
+					{Key: "tier", Operator: "Like", Values: []string{"web"}},
+				}}
+			},
+			want: []string{"spec.scope.labels.matchExpressions[1].operator"},
+		},
+		{
+			name:   "bad namespace pattern",
+			mutate: func(p *finopsv1alpha1.EnforcementPolicy) { p.Spec.Scope.Namespaces.Include = []string{"dev-["} },
+			want:   []string{"spec.scope.namespaces.include[0]"},
+		},
+		{
+			name: "reactivation without schedule or ttl",
+			mutate: func(p *finopsv1alpha1.EnforcementPolicy) {
+				p.Spec.Reactivation = &finopsv1alpha1.ReactivationSpec{}
+			},
+			want: []string{"spec.reactivation"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := validPolicy()
+			tt.mutate(p)
+
+			var got []string
+			for _, err := range ValidateSpec(p) {
+				got = append(got, err.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateSpec() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	p := validPolicy()
+	Default(&p.Spec)
+	if !p.Spec.Enforcement.IsDryRun() || p.Spec.Enforcement.DryRun == nil {
+		t.Errorf("DryRun = %v, want defaulted to true", p.Spec.Enforcement.DryRun)
+	}
+
+	enforce := false
+	p.Spec.Enforcement.DryRun = &enforce
+	Default(&p.Spec)
+	if p.Spec.Enforcement.IsDryRun() {
+		t.Error("explicit dryRun: false was overridden")
+	}
+}
+
+func TestProtectedNamespaceWarnings(t *testing.T) {
+	p := validPolicy()
+	p.Spec.Scope.Namespaces = finopsv1alpha1.NamespaceFilter{Include: []string{"*"}, Exclude: []string{"kube-public"}}
+
+	warnings := ProtectedNamespaceWarnings(p, DefaultProtectedNamespaces)
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "kube-system") {
+		t.Errorf("ProtectedNamespaceWarnings() = %v, want kube-system and kube-node-lease", warnings)
+	}
+
+	p.Spec.Scope.Namespaces = finopsv1alpha1.NamespaceFilter{Include: []string{"dev-*"}}
+	if warnings := ProtectedNamespaceWarnings(p, DefaultProtectedNamespaces); len(warnings) != 0 {
+		t.Errorf("ProtectedNamespaceWarnings() = %v, want none", warnings)
+	}
+}
@@ -0,0 +1,207 @@
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+)
+
+// DefaultTrafficQuery is the PromQL template used when a policy does not set
+// trafficThreshold.query. It reports Istio requests per minute per workload.
+const DefaultTrafficQuery = `sum(rate(istio_requests_total{destination_workload_namespace="{{.Namespace}}",destination_workload="{{.Name}}"}[{{.Window}}])) * 60`
+
+// ErrNoData is returned when a query returns an empty vector, e.g. because the
+// metric does not exist for the workload
+var ErrNoData = errors.New("query returned no data")
+
+// Client queries a Prometheus-compatible HTTP API
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient creates a new Prometheus client
+func NewClient(endpoint string, timeout time.Duration) *Client {
+	return &Client{
+		endpoint: endpoint,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// QueryParams are the values available to PromQL templates
+type QueryParams struct {
+	Namespace string
+	Name      string
+	Kind      string
+	Window    string
+	PodRegex  string
+}
+
+// RequestsPerMinute returns the observed request rate of a workload over the window.
+// queryTemplate is a Go text/template rendered with QueryParams; an empty
+// template falls back to DefaultTrafficQuery.
+func (c *Client) RequestsPerMinute(ctx context.Context, queryTemplate string, w *workload.Workload, window time.Duration) (float64, error) {
+	if queryTemplate == "" {
+		queryTemplate = DefaultTrafficQuery
+	}
+
+	query, err := RenderQuery(queryTemplate, w, window)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.Query(ctx, query)
+}
+
+// RenderQuery renders a PromQL template for a workload and window
+func RenderQuery(queryTemplate string, w *workload.Workload, window time.Duration) (string, error) {
+	tmpl, err := template.New("query").Option("missingkey=error").Parse(queryTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid query template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, QueryParams{
+		Namespace: w.GetNamespace(),
+		Name:      w.GetName(),
+		Kind:      string(w.Kind),
+		Window:    FormatRange(window),
+		PodRegex:  PodRegex(w),
+	}); err != nil {
+		return "", fmt.Errorf("failed to render query template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// Query runs an instant query and returns the sum of all returned samples.
+// An empty result returns ErrNoData rather than 0: a rate() over series that
+// saw no requests is 0, while an empty vector means the series are missing,
+// e.g. a workload without a sidecar or a query with a typo.
+func (c *Client) Query(ctx context.Context, query string) (float64, error) {
+	response, err := c.query(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := parseQueryResponse(response)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(response.Data.Result) == 0 {
+		return 0, ErrNoData
+	}
+
+	return value, nil
+}
+
+// query runs an instant query and returns the decoded response
+func (c *Client) query(ctx context.Context, query string) (QueryResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/query", c.endpoint)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return QueryResponse{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	q := req.URL.Query()
+	q.Add("query", query)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return QueryResponse{}, fmt.Errorf("failed to query prometheus: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return QueryResponse{}, fmt.Errorf("prometheus api error: status=%d, body=%s", resp.StatusCode, string(body))
+	}
+
+	var response QueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return QueryResponse{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response, nil
+}
+
+// QueryResponse represents the raw response from the Prometheus query API
+type QueryResponse struct {
+	Status string    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+	Data   QueryData `json:"data"`
+}
+
+// QueryData contains the query result
+type QueryData struct {
+	ResultType string        `json:"resultType"`
+	Result     []QuerySample `json:"result"`
+}
+
+// QuerySample represents a single vector sample
+type QuerySample struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+}
+
+// parseQueryResponse sums the sample values of a vector or scalar response
+func parseQueryResponse(response QueryResponse) (float64, error) {
+	if response.Status != "success" {
+		return 0, fmt.Errorf("prometheus query failed: %s", response.Error)
+	}
+
+	total := 0.0
+	for _, sample := range response.Data.Result {
+		value, err := sampleValue(sample.Value)
+		if err != nil {
+			return 0, err
+		}
+		total += value
+	}
+
+	return total, nil
+}
+
+// sampleValue extracts the float value from a [timestamp, "value"] pair
+func sampleValue(pair []interface{}) (float64, error) {
+	if len(pair) != 2 {
+		return 0, fmt.Errorf("unexpected sample format: %v", pair)
+	}
+
+	str, ok := pair[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected sample value: %v", pair[1])
+	}
+
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample value %q: %w", str, err)
+	}
+
+	return value, nil
+}
+
+// FormatRange converts time.Duration to a PromQL range selector
+func FormatRange(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
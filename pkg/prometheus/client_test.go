@@ -0,0 +1,226 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newFakePrometheus serves a canned /api/v1/query response and records the last query
+func newFakePrometheus(t *testing.T, status int, body string, lastQuery *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if lastQuery != nil {
+			*lastQuery = r.URL.Query().Get("query")
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testWorkload() *workload.Workload {
+	return workload.FromDeployment(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "dev-team"},
+	})
+}
+
+func TestClient_RequestsPerMinute(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:   "vector result is summed",
+			status: http.StatusOK,
+			body: `{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"pod":"a"},"value":[1700000000,"1.5"]},
+				{"metric":{"pod":"b"},"value":[1700000000,"2.5"]}]}}`,
+			want: 4.0,
+		},
+		{
+			name:   "zero sample means no traffic",
+			status: http.StatusOK,
+			body:   `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0"]}]}}`,
+			want:   0,
+		},
+		{
+			name:    "empty result is an error",
+			status:  http.StatusOK,
+			body:    `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			wantErr: true,
+		},
+		{
+			name:    "query error",
+			status:  http.StatusOK,
+			body:    `{"status":"error","error":"parse error"}`,
+			wantErr: true,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			body:    `oops`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakePrometheus(t, tt.status, tt.body, nil)
+			client := NewClient(server.URL, 5*time.Second)
+
+			got, err := client.RequestsPerMinute(context.Background(), "", testWorkload(), 48*time.Hour)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RequestsPerMinute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RequestsPerMinute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_RendersQueryTemplate(t *testing.T) {
+	var lastQuery string
+	server := newFakePrometheus(t, http.StatusOK,
+		`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0"]}]}}`, &lastQuery)
+	client := NewClient(server.URL, 5*time.Second)
+
+	tmpl := `sum(rate(nginx_ingress_controller_requests{exported_namespace="{{.Namespace}}",exported_service="{{.Name}}"}[{{.Window}}])) * 60`
+	if _, err := client.RequestsPerMinute(context.Background(), tmpl, testWorkload(), 90*time.Minute); err != nil {
+		t.Fatalf("RequestsPerMinute() error = %v", err)
+	}
+
+	want := `sum(rate(nginx_ingress_controller_requests{exported_namespace="dev-team",exported_service="api"}[90m])) * 60`
+	if lastQuery != want {
+		t.Errorf("query = %q, want %q", lastQuery, want)
+	}
+}
+
+func TestRenderQueryInvalidTemplate(t *testing.T) {
+	if _, err := RenderQuery("{{.Unknown}}", testWorkload(), time.Hour); err == nil {
+		t.Error("RenderQuery() expected error for unknown field")
+	}
+}
+
+func TestFormatRange(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{duration: 48 * time.Hour, want: "48h"},
+		{duration: 90 * time.Minute, want: "90m"},
+		{duration: 45 * time.Second, want: "45s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := FormatRange(tt.duration)
+			if got != tt.want {
+				t.Errorf("FormatRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_Utilization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := "3.5"
+		if strings.Contains(r.URL.Query().Get("query"), "container_memory_working_set_bytes") {
+			value = "12.25"
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"` + value + `"]}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, 5*time.Second)
+	cpu, memory, err := client.Utilization(context.Background(), testWorkload(), 48*time.Hour)
+	if err != nil {
+		t.Fatalf("Utilization() error = %v", err)
+	}
+	if cpu != 3.5 || memory != 12.25 {
+		t.Errorf("Utilization() = (%v, %v), want (3.5, 12.25)", cpu, memory)
+	}
+}
+
+func TestClient_UtilizationNoData(t *testing.T) {
+	server := newFakePrometheus(t, http.StatusOK,
+		`{"status":"success","data":{"resultType":"vector","result":[]}}`, nil)
+	client := NewClient(server.URL, 5*time.Second)
+
+	if _, _, err := client.Utilization(context.Background(), testWorkload(), time.Hour); !errors.Is(err, ErrNoData) {
+		t.Errorf("Utilization() error = %v, want ErrNoData", err)
+	}
+}
+
+func TestPodRegex(t *testing.T) {
+	tests := []struct {
+		name     string
+		workload *workload.Workload
+		want     string
+	}{
+		{
+			name:     "deployment",
+			workload: testWorkload(),
+			want:     "api-[a-z0-9]+-[a-z0-9]+",
+		},
+		{
+			name: "statefulset",
+			workload: workload.FromStatefulSet(&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db.primary", Namespace: "dev-team"},
+			}),
+			want: `db\\.primary-[0-9]+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PodRegex(tt.workload); got != tt.want {
+				t.Errorf("PodRegex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodRegexInQuery(t *testing.T) {
+	w := workload.FromDeployment(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web.api", Namespace: "dev-team"},
+	})
+
+	query, err := RenderQuery(`up{pod=~"{{.PodRegex}}"}`, w, time.Hour)
+	if err != nil {
+		t.Fatalf("RenderQuery() error = %v", err)
+	}
+
+	// PromQL double-quoted strings unescape like Go string literals
+	literal := strings.TrimSuffix(strings.TrimPrefix(query, `up{pod=~`), `}`)
+	expr, err := strconv.Unquote(literal)
+	if err != nil {
+		t.Fatalf("pod matcher %s is not a valid string literal: %v", literal, err)
+	}
+	re := regexp.MustCompile("^(?:" + expr + ")$")
+
+	if !re.MatchString("web.api-5d8f7c9b4-x2x9q") {
+		t.Errorf("pod regex %q does not match a pod of web.api", expr)
+	}
+	if re.MatchString("webxapi-5d8f7c9b4-x2x9q") {
+		t.Errorf("pod regex %q matches a pod of another workload", expr)
+	}
+}
@@ -0,0 +1,68 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+)
+
+// DefaultCPUUtilizationQuery reports average CPU usage over the window as a
+// percentage of CPU requests (cAdvisor + kube-state-metrics)
+const DefaultCPUUtilizationQuery = `sum(rate(container_cpu_usage_seconds_total{namespace="{{.Namespace}}",pod=~"{{.PodRegex}}",container!=""}[{{.Window}}])) / sum(kube_pod_container_resource_requests{namespace="{{.Namespace}}",pod=~"{{.PodRegex}}",resource="cpu"}) * 100`
+
+// DefaultMemoryUtilizationQuery reports average working set memory over the
+// window as a percentage of memory requests (cAdvisor + kube-state-metrics)
+const DefaultMemoryUtilizationQuery = `sum(avg_over_time(container_memory_working_set_bytes{namespace="{{.Namespace}}",pod=~"{{.PodRegex}}",container!=""}[{{.Window}}])) / sum(kube_pod_container_resource_requests{namespace="{{.Namespace}}",pod=~"{{.PodRegex}}",resource="memory"}) * 100`
+
+// Utilization returns the average CPU and memory utilization of a workload
+// over the window, as percentages of the requested resources
+func (c *Client) Utilization(ctx context.Context, w *workload.Workload, window time.Duration) (float64, float64, error) {
+	cpu, err := c.utilization(ctx, DefaultCPUUtilizationQuery, w, window)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query cpu utilization: %w", err)
+	}
+
+	memory, err := c.utilization(ctx, DefaultMemoryUtilizationQuery, w, window)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query memory utilization: %w", err)
+	}
+
+	return cpu, memory, nil
+}
+
+// utilization runs a utilization query, treating an empty result as an error
+// since it means the pods have no resource requests or no metrics
+func (c *Client) utilization(ctx context.Context, queryTemplate string, w *workload.Workload, window time.Duration) (float64, error) {
+	query, err := RenderQuery(queryTemplate, w, window)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := c.Query(ctx, query)
+	if errors.Is(err, ErrNoData) {
+		return 0, fmt.Errorf("%w for %s", err, w.Key())
+	}
+
+	return value, err
+}
+
+// PodRegex returns a regular expression matching the pod names created for a
+// workload, escaped for use inside a double-quoted PromQL string: the
+// backslashes QuoteMeta adds for dots in names are doubled, since PromQL
+// strings treat a backslash as an escape
+func PodRegex(w *workload.Workload) string {
+	name := strings.ReplaceAll(regexp.QuoteMeta(w.GetName()), `\`, `\\`)
+	switch w.Kind {
+	case workload.KindStatefulSet:
+		return name + "-[0-9]+"
+	case workload.KindCronJob:
+		return name + "-[0-9]+-[a-z0-9]+"
+	default:
+		return name + "-[a-z0-9]+-[a-z0-9]+"
+	}
+}
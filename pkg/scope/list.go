@@ -0,0 +1,66 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListNamespaces returns every namespace by name
+func ListNamespaces(ctx context.Context, c client.Reader) (map[string]*corev1.Namespace, error) {
+	list := &corev1.NamespaceList{}
+	if err := c.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	namespaces := make(map[string]*corev1.Namespace, len(list.Items))
+	for i := range list.Items {
+		namespaces[list.Items[i].Name] = &list.Items[i]
+	}
+	return namespaces, nil
+}
+
+// List returns the workloads of the selected kinds matching a scope. Only the
+// namespaces in scope are listed, one at a time, with the label filter as a
+// server-side selector, so workloads out of scope are never fetched.
+// Workloads in namespaces missing from namespaces are not listed.
+func List(
+	ctx context.Context,
+	c client.Reader,
+	spec finopsv1alpha1.ScopeSpec,
+	namespaces map[string]*corev1.Namespace,
+) ([]*workload.Workload, error) {
+	selector, err := LabelSelector(spec.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label filter: %w", err)
+	}
+
+	names := []string{}
+	for name, namespace := range namespaces {
+		if inScope, _ := NamespaceInScope(namespace, spec); inScope {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	matched := []*workload.Workload{}
+	for _, kind := range Kinds(spec) {
+		for _, name := range names {
+			workloads, err := workload.List(ctx, c, kind,
+				client.InNamespace(name),
+				client.MatchingLabelsSelector{Selector: selector},
+			)
+			if err != nil {
+				return nil, err
+			}
+			matched = append(matched, workloads...)
+		}
+	}
+
+	return matched, nil
+}
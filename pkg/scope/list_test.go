@@ -0,0 +1,89 @@
+package scope
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// recordingReader records the namespaces workloads are listed in
+type recordingReader struct {
+	client.Reader
+	listed []string
+}
+
+func (r *recordingReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if _, ok := list.(*appsv1.DeploymentList); ok {
+		listOpts := &client.ListOptions{}
+		listOpts.ApplyOptions(opts)
+		r.listed = append(r.listed, listOpts.Namespace)
+	}
+	return r.Reader.List(ctx, list, opts...)
+}
+
+func TestList(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	newNamespace := func(name string, labels, annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations}}
+	}
+	newDeployment := func(namespace, name string, labels map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}}
+	}
+	reader := &recordingReader{Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newNamespace("payments-preview", map[string]string{"env": "dev"}, nil),
+		newNamespace("search-preview", map[string]string{"env": "staging"}, nil),
+		newNamespace("legacy-preview", map[string]string{"env": "dev"}, map[string]string{NamespaceExcludeAnnotation: "true"}),
+		newNamespace("checkout-preview", map[string]string{"env": "dev"}, nil),
+		newNamespace("prod", map[string]string{"env": "dev"}, nil),
+		newDeployment("payments-preview", "api", map[string]string{"team": "payments"}),
+		newDeployment("payments-preview", "worker", map[string]string{"team": "payments", "critical": "true"}),
+		newDeployment("search-preview", "api", map[string]string{"team": "payments"}),
+		newDeployment("legacy-preview", "api", map[string]string{"team": "payments"}),
+		newDeployment("checkout-preview", "api", map[string]string{"team": "search"}),
+		newDeployment("prod", "api", map[string]string{"team": "payments"}),
+	).Build()}
+
+	spec := finopsv1alpha1.ScopeSpec{
+		Namespaces:        finopsv1alpha1.NamespaceFilter{Include: []string{"*-preview"}},
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+		Labels: &finopsv1alpha1.LabelFilter{
+			Exclude: map[string]string{"critical": "true"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"payments", "checkout"}},
+			},
+		},
+	}
+
+	namespaces, err := ListNamespaces(context.Background(), reader)
+	if err != nil {
+		t.Fatalf("ListNamespaces() error = %v", err)
+	}
+	workloads, err := List(context.Background(), reader, spec, namespaces)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	var got []string
+	for _, w := range workloads {
+		got = append(got, w.GetNamespace()+"/"+w.GetName())
+	}
+	if want := []string{"payments-preview/api"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+
+	// Only namespaces in scope are listed
+	if want := []string{"checkout-preview", "payments-preview"}; !reflect.DeepEqual(reader.listed, want) {
+		t.Errorf("listed namespaces = %v, want %v", reader.listed, want)
+	}
+}
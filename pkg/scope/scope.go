@@ -0,0 +1,259 @@
+// Package scope resolves which workloads a policy scope selects. The
+// reconciler lists workloads with it and the policy engine evaluates them with
+// it, so both agree on namespace patterns, selectors and label filters.
+package scope
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// RegexPrefix marks a namespace pattern as a regular expression, e.g.
+// re:^team-[a-z]+-dev$; other patterns are globs such as dev-* or team-?-dev
+const RegexPrefix = "re:"
+
+// Namespace annotations opting a whole namespace out of or in to enforcement
+const (
+	// NamespaceExcludeAnnotation set to "true" keeps every policy out of the
+	// namespace, like finops.io/exclude on a single workload
+	NamespaceExcludeAnnotation = "finops.io/exclude"
+	// NamespaceOptInAnnotation set to "true" admits the namespace to policies
+	// with spec.scope.namespaces.requireOptIn
+	NamespaceOptInAnnotation = "finops.io/opt-in"
+)
+
+// regexps caches compiled namespace regexes, since the same few patterns are
+// matched against every workload on every reconcile
+var regexps sync.Map
+
+// compiledRegexp is a cached compile result
+type compiledRegexp struct {
+	re  *regexp.Regexp
+	err error
+}
+
+// compileRegexp compiles a regex pattern without its prefix. Regexes must
+// match the whole namespace name.
+func compileRegexp(expr string) (*regexp.Regexp, error) {
+	if cached, ok := regexps.Load(expr); ok {
+		compiled := cached.(compiledRegexp)
+		return compiled.re, compiled.err
+	}
+
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	regexps.Store(expr, compiledRegexp{re: re, err: err})
+	return re, err
+}
+
+// ValidatePattern checks that a namespace pattern is a valid glob or regex
+func ValidatePattern(pattern string) error {
+	if expr, ok := strings.CutPrefix(pattern, RegexPrefix); ok {
+		if _, err := compileRegexp(expr); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid glob: %w", err)
+	}
+	return nil
+}
+
+// MatchPattern reports whether value matches a glob or re: regex pattern.
+// Invalid patterns match nothing.
+func MatchPattern(pattern, value string) bool {
+	if expr, ok := strings.CutPrefix(pattern, RegexPrefix); ok {
+		re, err := compileRegexp(expr)
+		return err == nil && re.MatchString(value)
+	}
+
+	matched, _ := filepath.Match(pattern, value)
+	return matched
+}
+
+// MatchesNamespaceName reports whether a namespace name matches the include
+// patterns and none of the exclude patterns
+func MatchesNamespaceName(name string, filter finopsv1alpha1.NamespaceFilter) bool {
+	for _, pattern := range filter.Exclude {
+		if MatchPattern(pattern, name) {
+			return false
+		}
+	}
+
+	for _, pattern := range filter.Include {
+		if MatchPattern(pattern, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NamespaceSelected checks a namespace against the parts of a scope that need
+// the Namespace object: opt-out and opt-in annotations and the namespace
+// selector. It returns the reason when the namespace is not selected.
+func NamespaceSelected(namespace *corev1.Namespace, spec finopsv1alpha1.ScopeSpec) (bool, string) {
+	annotations := namespace.GetAnnotations()
+	if annotations[NamespaceExcludeAnnotation] == "true" {
+		return false, "namespace excluded by annotation"
+	}
+	if spec.Namespaces.RequireOptIn && annotations[NamespaceOptInAnnotation] != "true" {
+		return false, "namespace not opted in"
+	}
+
+	if spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector)
+		if err != nil || !selector.Matches(labels.Set(namespace.GetLabels())) {
+			return false, "namespace labels do not match"
+		}
+	}
+
+	return true, ""
+}
+
+// NamespaceInScope checks a namespace against every namespace filter of a
+// scope and returns the reason when it is not in scope
+func NamespaceInScope(namespace *corev1.Namespace, spec finopsv1alpha1.ScopeSpec) (bool, string) {
+	if !MatchesNamespaceName(namespace.GetName(), spec.Namespaces) {
+		return false, "namespace not in scope"
+	}
+	return NamespaceSelected(namespace, spec)
+}
+
+// LabelSelector converts a label filter to a selector usable both in memory
+// and as a server-side list option. Match labels must be present with the
+// given value, and exclude labels must not carry the given value.
+func LabelSelector(filter *finopsv1alpha1.LabelFilter) (labels.Selector, error) {
+	if filter == nil {
+		return labels.Everything(), nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels:      filter.Match,
+		MatchExpressions: filter.MatchExpressions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Sorted so the selector string is stable across reconciles
+	keys := make([]string, 0, len(filter.Exclude))
+	for key := range filter.Exclude {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		requirement, err := labels.NewRequirement(key, selection.NotEquals, []string{filter.Exclude[key]})
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*requirement)
+	}
+
+	return selector, nil
+}
+
+// MatchesLabels reports whether labels satisfy a label filter. Invalid
+// filters match nothing.
+func MatchesLabels(set map[string]string, filter *finopsv1alpha1.LabelFilter) bool {
+	selector, err := LabelSelector(filter)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(set))
+}
+
+// Kinds returns the workload kinds selected by a scope, defaulting to
+// Deployments when none are listed
+func Kinds(spec finopsv1alpha1.ScopeSpec) []workload.Kind {
+	if len(spec.Kinds) == 0 {
+		return []workload.Kind{workload.KindDeployment}
+	}
+
+	kinds := make([]workload.Kind, 0, len(spec.Kinds))
+	for _, kind := range spec.Kinds {
+		kinds = append(kinds, workload.Kind(kind))
+	}
+
+	return kinds
+}
+
+// MatchesKind reports whether a workload kind is selected by a scope
+func MatchesKind(kind workload.Kind, spec finopsv1alpha1.ScopeSpec) bool {
+	for _, k := range Kinds(spec) {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches checks a workload in namespace against a scope: its kind,
+// namespace patterns, namespace selector and annotations, and label filters.
+// Scope expressions need compiled programs and are left to the policy engine.
+// It returns the reason when the workload is not in scope.
+func Matches(spec finopsv1alpha1.ScopeSpec, w *workload.Workload, namespace *corev1.Namespace) (bool, string) {
+	if !MatchesKind(w.Kind, spec) {
+		return false, "kind not in scope"
+	}
+	if inScope, reason := NamespaceInScope(namespace, spec); !inScope {
+		return false, reason
+	}
+	if !MatchesLabels(w.GetLabels(), spec.Labels) {
+		return false, "labels do not match"
+	}
+	return true, ""
+}
+
+// Validate checks the namespace patterns, namespace selector and label
+// filters of a scope
+func Validate(spec finopsv1alpha1.ScopeSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	namespacesPath := path.Child("namespaces")
+	if len(spec.Namespaces.Include) == 0 {
+		errs = append(errs, field.Required(namespacesPath.Child("include"), "at least one namespace pattern is required"))
+	}
+	for i, pattern := range spec.Namespaces.Include {
+		if err := ValidatePattern(pattern); err != nil {
+			errs = append(errs, field.Invalid(namespacesPath.Child("include").Index(i), pattern, err.Error()))
+		}
+	}
+	for i, pattern := range spec.Namespaces.Exclude {
+		if err := ValidatePattern(pattern); err != nil {
+			errs = append(errs, field.Invalid(namespacesPath.Child("exclude").Index(i), pattern, err.Error()))
+		}
+	}
+
+	if spec.NamespaceSelector != nil {
+		errs = append(errs, metav1validation.ValidateLabelSelector(spec.NamespaceSelector,
+			metav1validation.LabelSelectorValidationOptions{}, path.Child("namespaceSelector"))...)
+	}
+
+	if spec.Labels != nil {
+		labelsPath := path.Child("labels")
+		errs = append(errs, metav1validation.ValidateLabels(spec.Labels.Match, labelsPath.Child("match"))...)
+		errs = append(errs, metav1validation.ValidateLabels(spec.Labels.Exclude, labelsPath.Child("exclude"))...)
+		for i, requirement := range spec.Labels.MatchExpressions {
+			errs = append(errs, metav1validation.ValidateLabelSelectorRequirement(requirement,
+				metav1validation.LabelSelectorValidationOptions{}, labelsPath.Child("matchExpressions").Index(i))...)
+		}
+	}
+
+	return errs
+}
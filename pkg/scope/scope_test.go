@@ -0,0 +1,395 @@
+package scope
+
+import (
+	"reflect"
+	"testing"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{pattern: "dev-*", value: "dev-test", want: true},
+		{pattern: "dev-*", value: "prod-test", want: false},
+		{pattern: "*", value: "anything", want: true},
+		{pattern: "exact", value: "exact", want: true},
+		{pattern: "exact", value: "not-exact", want: false},
+		{pattern: "*-preview", value: "payments-preview", want: true},
+		{pattern: "team-?-dev", value: "team-a-dev", want: true},
+		{pattern: "team-?-dev", value: "team-ab-dev", want: false},
+		{pattern: "re:team-[a-z]+-dev", value: "team-payments-dev", want: true},
+		{pattern: "re:team-[a-z]+-dev", value: "team-payments-dev-2", want: false},
+		{pattern: "re:dev|staging", value: "staging", want: true},
+		{pattern: "re:dev|staging", value: "dev-staging", want: false},
+		{pattern: "re:team-(", value: "team-(", want: false},
+		{pattern: "dev-[", value: "dev-[", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.value, func(t *testing.T) {
+			got := MatchPattern(tt.pattern, tt.value)
+			if got != tt.want {
+				t.Errorf("MatchPattern(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesNamespaceName(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		filter    finopsv1alpha1.NamespaceFilter
+		want      bool
+	}{
+		{
+			name:      "exact match",
+			namespace: "dev-test",
+			filter:    finopsv1alpha1.NamespaceFilter{Include: []string{"dev-test"}},
+			want:      true,
+		},
+		{
+			name:      "wildcard match",
+			namespace: "dev-feature-xyz",
+			filter:    finopsv1alpha1.NamespaceFilter{Include: []string{"dev-*"}},
+			want:      true,
+		},
+		{
+			name:      "leading wildcard match",
+			namespace: "payments-preview",
+			filter:    finopsv1alpha1.NamespaceFilter{Include: []string{"*-preview"}},
+			want:      true,
+		},
+		{
+			name:      "excluded namespace",
+			namespace: "prod",
+			filter:    finopsv1alpha1.NamespaceFilter{Include: []string{"*"}, Exclude: []string{"prod"}},
+			want:      false,
+		},
+		{
+			name:      "excluded by regex",
+			namespace: "prod-eu-1",
+			filter:    finopsv1alpha1.NamespaceFilter{Include: []string{"*"}, Exclude: []string{"re:prod-[a-z]+-[0-9]+"}},
+			want:      false,
+		},
+		{
+			name:      "no match",
+			namespace: "staging",
+			filter:    finopsv1alpha1.NamespaceFilter{Include: []string{"dev-*"}},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MatchesNamespaceName(tt.namespace, tt.filter)
+			if got != tt.want {
+				t.Errorf("MatchesNamespaceName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespaceSelected(t *testing.T) {
+	newNamespace := func(labels, annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev-a", Labels: labels, Annotations: annotations}}
+	}
+	devSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"env": "dev"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "finops.io/tier", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"critical"}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		namespace  *corev1.Namespace
+		spec       finopsv1alpha1.ScopeSpec
+		wantReason string
+	}{
+		{
+			name:      "no selector",
+			namespace: newNamespace(nil, nil),
+		},
+		{
+			name:      "selector matches",
+			namespace: newNamespace(map[string]string{"env": "dev"}, nil),
+			spec:      finopsv1alpha1.ScopeSpec{NamespaceSelector: devSelector},
+		},
+		{
+			name:       "selector label mismatch",
+			namespace:  newNamespace(map[string]string{"env": "prod"}, nil),
+			spec:       finopsv1alpha1.ScopeSpec{NamespaceSelector: devSelector},
+			wantReason: "namespace labels do not match",
+		},
+		{
+			name:       "selector expression mismatch",
+			namespace:  newNamespace(map[string]string{"env": "dev", "finops.io/tier": "critical"}, nil),
+			spec:       finopsv1alpha1.ScopeSpec{NamespaceSelector: devSelector},
+			wantReason: "namespace labels do not match",
+		},
+		{
+			name:       "opted out",
+			namespace:  newNamespace(map[string]string{"env": "dev"}, map[string]string{NamespaceExcludeAnnotation: "true"}),
+			spec:       finopsv1alpha1.ScopeSpec{NamespaceSelector: devSelector},
+			wantReason: "namespace excluded by annotation",
+		},
+		{
+			name:      "opted in",
+			namespace: newNamespace(nil, map[string]string{NamespaceOptInAnnotation: "true"}),
+			spec:      finopsv1alpha1.ScopeSpec{Namespaces: finopsv1alpha1.NamespaceFilter{RequireOptIn: true}},
+		},
+		{
+			name:       "opt-in required",
+			namespace:  newNamespace(nil, nil),
+			spec:       finopsv1alpha1.ScopeSpec{Namespaces: finopsv1alpha1.NamespaceFilter{RequireOptIn: true}},
+			wantReason: "namespace not opted in",
+		},
+		{
+			name:       "opt-out wins over opt-in",
+			namespace:  newNamespace(nil, map[string]string{NamespaceOptInAnnotation: "true", NamespaceExcludeAnnotation: "true"}),
+			spec:       finopsv1alpha1.ScopeSpec{Namespaces: finopsv1alpha1.NamespaceFilter{RequireOptIn: true}},
+			wantReason: "namespace excluded by annotation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, reason := NamespaceSelected(tt.namespace, tt.spec)
+			if selected != (tt.wantReason == "") {
+				t.Errorf("NamespaceSelected() = %v, want %v", selected, tt.wantReason == "")
+			}
+			if reason != tt.wantReason {
+				t.Errorf("NamespaceSelected() reason = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestMatchesLabels(t *testing.T) {
+	labels := map[string]string{"team": "payments", "tier": "web"}
+
+	tests := []struct {
+		name   string
+		filter *finopsv1alpha1.LabelFilter
+		want   bool
+	}{
+		{name: "no filter", want: true},
+		{
+			name:   "match",
+			filter: &finopsv1alpha1.LabelFilter{Match: map[string]string{"team": "payments"}},
+			want:   true,
+		},
+		{
+			name:   "match missing label",
+			filter: &finopsv1alpha1.LabelFilter{Match: map[string]string{"env": "dev"}},
+			want:   false,
+		},
+		{
+			name:   "exclude",
+			filter: &finopsv1alpha1.LabelFilter{Exclude: map[string]string{"tier": "web"}},
+			want:   false,
+		},
+		{
+			name:   "exclude other value",
+			filter: &finopsv1alpha1.LabelFilter{Exclude: map[string]string{"tier": "db", "critical": "true"}},
+			want:   true,
+		},
+		{
+			name: "in",
+			filter: &finopsv1alpha1.LabelFilter{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"payments", "search"}},
+			}},
+			want: true,
+		},
+		{
+			name: "not in",
+			filter: &finopsv1alpha1.LabelFilter{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"web"}},
+			}},
+			want: false,
+		},
+		{
+			name: "exists and does not exist",
+			filter: &finopsv1alpha1.LabelFilter{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpExists},
+				{Key: "finops.io/pinned", Operator: metav1.LabelSelectorOpDoesNotExist},
+			}},
+			want: true,
+		},
+		{
+			name: "invalid operator",
+			filter: &finopsv1alpha1.LabelFilter{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: "Like", Values: []string{"pay"}},
+			}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesLabels(labels, tt.filter); got != tt.want {
+				t.Errorf("MatchesLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesKind(t *testing.T) {
+	tests := []struct {
+		name string
+		spec finopsv1alpha1.ScopeSpec
+		kind workload.Kind
+		want bool
+	}{
+		{
+			name: "default selects deployments",
+			kind: workload.KindDeployment,
+			want: true,
+		},
+		{
+			name: "default skips statefulsets",
+			kind: workload.KindStatefulSet,
+			want: false,
+		},
+		{
+			name: "explicit statefulset",
+			spec: finopsv1alpha1.ScopeSpec{
+				Kinds: []finopsv1alpha1.WorkloadKind{finopsv1alpha1.WorkloadKindStatefulSet},
+			},
+			kind: workload.KindStatefulSet,
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MatchesKind(tt.kind, tt.spec)
+			if got != tt.want {
+				t.Errorf("MatchesKind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	spec := finopsv1alpha1.ScopeSpec{
+		Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"re:team-[a-z]+-dev"}},
+		Labels:     &finopsv1alpha1.LabelFilter{Exclude: map[string]string{"critical": "true"}},
+	}
+	newDeployment := func(namespace string, labels map[string]string) *workload.Workload {
+		return workload.FromDeployment(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace, Labels: labels},
+		})
+	}
+
+	tests := []struct {
+		name       string
+		workload   *workload.Workload
+		wantReason string
+	}{
+		{
+			name:     "in scope",
+			workload: newDeployment("team-payments-dev", nil),
+		},
+		{
+			name:       "namespace not in scope",
+			workload:   newDeployment("team-payments-prod", nil),
+			wantReason: "namespace not in scope",
+		},
+		{
+			name:       "excluded label",
+			workload:   newDeployment("team-payments-dev", map[string]string{"critical": "true"}),
+			wantReason: "labels do not match",
+		},
+		{
+			name: "kind not in scope",
+			workload: workload.FromStatefulSet(&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-payments-dev"},
+			}),
+			wantReason: "kind not in scope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: tt.workload.GetNamespace()}}
+			inScope, reason := Matches(spec, tt.workload, namespace)
+			if inScope != (tt.wantReason == "") || reason != tt.wantReason {
+				t.Errorf("Matches() = %v, %q, want reason %q", inScope, reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		spec finopsv1alpha1.ScopeSpec
+		want []string
+	}{
+		{
+			name: "valid",
+			spec: finopsv1alpha1.ScopeSpec{Namespaces: finopsv1alpha1.NamespaceFilter{
+				Include: []string{"dev-*", "re:team-[a-z]+-dev"},
+				Exclude: []string{"*-critical"},
+			}},
+		},
+		{
+			name: "no include",
+			want: []string{"spec.scope.namespaces.include"},
+		},
+		{
+			name: "bad glob and regex",
+			spec: finopsv1alpha1.ScopeSpec{Namespaces: finopsv1alpha1.NamespaceFilter{
+				Include: []string{"dev-["},
+				Exclude: []string{"re:prod-("},
+			}},
+			want: []string{"spec.scope.namespaces.include[0]", "spec.scope.namespaces.exclude[0]"},
+		},
+		{
+			name: "invalid namespace selector",
+			spec: finopsv1alpha1.ScopeSpec{
+				Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"*"}},
+				NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "env", Operator: metav1.LabelSelectorOpIn},
+				}},
+			},
+			want: []string{"spec.scope.namespaceSelector.matchExpressions[0].values"},
+		},
+		{
+			name: "invalid label filters",
+			spec: finopsv1alpha1.ScopeSpec{
+				Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{"*"}},
+				Labels: &finopsv1alpha1.LabelFilter{
+					Exclude: map[string]string{"critical": "yes please"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "tier", Operator: "Like", Values: []string{"web"}},
+					},
+				},
+			},
+			want: []string{"spec.scope.labels.exclude", "spec.scope.labels.matchExpressions[0].operator"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, err := range Validate(tt.spec, field.NewPath("spec", "scope")) {
+				got = append(got, err.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// LeaderLabel marks the pod holding the leader lease, so a Service selecting
+// it routes only to the leader
+const LeaderLabel = "finops.io/leader"
+
+// LeaderLabeler labels its own pod with LeaderLabel while it is the leader.
+// Leader-only servers sit behind a Service selecting the label.
+type LeaderLabeler struct {
+	Client    client.Client
+	Namespace string
+	Name      string
+}
+
+// Start labels the pod and removes the label when leadership ends
+func (l *LeaderLabeler) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithValues("pod", l.Namespace+"/"+l.Name)
+
+	if err := setLeaderLabel(ctx, l.Client, l.Namespace, l.Name, `"true"`); err != nil {
+		return err
+	}
+	logger.Info("labeled pod as leader")
+
+	<-ctx.Done()
+
+	clearCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := ClearLeaderLabel(clearCtx, l.Client, l.Namespace, l.Name); err != nil {
+		logger.Error(err, "failed to remove leader label")
+	}
+	return nil
+}
+
+// NeedLeaderElection reports that the labeler runs on the leader only
+func (l *LeaderLabeler) NeedLeaderElection() bool {
+	return true
+}
+
+// ClearLeaderLabel removes LeaderLabel from a pod, e.g. one left behind by a
+// previous process in the same pod
+func ClearLeaderLabel(ctx context.Context, c client.Client, namespace, name string) error {
+	return setLeaderLabel(ctx, c, namespace, name, "null")
+}
+
+// setLeaderLabel merge-patches LeaderLabel to a JSON value, null removing it
+func setLeaderLabel(ctx context.Context, c client.Client, namespace, name, value string) error {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	patch := fmt.Sprintf(`{"metadata":{"labels":{%q:%s}}}`, LeaderLabel, value)
+	if err := c.Patch(ctx, pod, client.RawPatch(types.MergePatchType, []byte(patch))); err != nil {
+		return fmt.Errorf("failed to patch leader label on pod %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
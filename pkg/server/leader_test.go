@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLeaderLabeler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "finops-enforcer-0",
+		Namespace: "finops-system",
+		Labels:    map[string]string{"app": "finops-enforcer"},
+	}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod).Build()
+	labels := func() map[string]string {
+		got := &corev1.Pod{}
+		if err := c.Get(context.Background(), client.ObjectKeyFromObject(pod), got); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		return got.Labels
+	}
+
+	labeler := &LeaderLabeler{Client: c, Namespace: pod.Namespace, Name: pod.Name}
+	if !labeler.NeedLeaderElection() {
+		t.Error("NeedLeaderElection() = false, want true")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- labeler.Start(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for labels()[LeaderLabel] != "true" {
+		if time.Now().After(deadline) {
+			t.Fatal("pod was not labeled as leader")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	got := labels()
+	if _, ok := got[LeaderLabel]; ok {
+		t.Errorf("leader label not removed: %v", got)
+	}
+	if got["app"] != "finops-enforcer" {
+		t.Errorf("other labels changed: %v", got)
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Server runs an HTTP server as a manager Runnable. It serves on every
+// replica unless LeaderOnly is set; leader-only servers hold state such as
+// the action budget that only the leader's controllers see, and sit behind a
+// Service selecting LeaderLabel.
+type Server struct {
+	Name       string
+	Addr       string
+	Handler    http.Handler
+	LeaderOnly bool
+}
+
+// Start serves until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithValues("server", s.Name, "addr", s.Addr)
+
+	srv := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	shutdown := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		logger.Info("shutting down server")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "error shutting down server")
+		}
+		close(shutdown)
+	}()
+
+	logger.Info("starting server")
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	<-shutdown
+	return nil
+}
+
+// NeedLeaderElection reports whether the server runs on the leader only
+func (s *Server) NeedLeaderElection() bool {
+	return s.LeaderOnly
+}
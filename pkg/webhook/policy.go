@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	"github.com/yourusername/finops-enforcer/pkg/policy"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// PolicyWebhook defaults and validates EnforcementPolicy and
+// ClusterEnforcementPolicy objects on admission
+type PolicyWebhook struct {
+	// ProtectedNamespaces are namespaces a policy scope is warned about
+	// matching, e.g. kube-system
+	ProtectedNamespaces []string
+}
+
+// +kubebuilder:webhook:path=/mutate-finops-io-v1alpha1-enforcementpolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=finops.io,resources=enforcementpolicies,verbs=create;update,versions=v1alpha1,name=menforcementpolicy.finops.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-finops-io-v1alpha1-enforcementpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=finops.io,resources=enforcementpolicies,verbs=create;update,versions=v1alpha1,name=venforcementpolicy.finops.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-finops-io-v1alpha1-clusterenforcementpolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=finops.io,resources=clusterenforcementpolicies,verbs=create;update,versions=v1alpha1,name=mclusterenforcementpolicy.finops.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-finops-io-v1alpha1-clusterenforcementpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=finops.io,resources=clusterenforcementpolicies,verbs=create;update,versions=v1alpha1,name=vclusterenforcementpolicy.finops.io,admissionReviewVersions=v1
+
+// SetupWithManager registers the defaulting and validating webhooks
+func (w *PolicyWebhook) SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&finopsv1alpha1.EnforcementPolicy{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete(); err != nil {
+		return err
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&finopsv1alpha1.ClusterEnforcementPolicy{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// Default sets dryRun to true on new policies that do not set it. Updates are
+// left alone so an existing policy without dryRun keeps enforcing.
+func (w *PolicyWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get admission request: %w", err)
+	}
+	if req.Operation != admissionv1.Create {
+		return nil
+	}
+
+	switch p := obj.(type) {
+	case *finopsv1alpha1.EnforcementPolicy:
+		policy.Default(&p.Spec)
+	case *finopsv1alpha1.ClusterEnforcementPolicy:
+		policy.Default(&p.Spec)
+	default:
+		return fmt.Errorf("expected an EnforcementPolicy or ClusterEnforcementPolicy, got %T", obj)
+	}
+	return nil
+}
+
+// ValidateCreate rejects malformed policies and warns about scopes that miss
+// the policy's own namespace or match protected namespaces
+func (w *PolicyWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return w.validate(obj)
+}
+
+// ValidateUpdate rejects malformed policies and warns about scopes that miss
+// the policy's own namespace or match protected namespaces
+func (w *PolicyWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return w.validate(newObj)
+}
+
+// ValidateDelete allows every deletion
+func (w *PolicyWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks the spec of a policy being admitted
+func (w *PolicyWebhook) validate(obj runtime.Object) (admission.Warnings, error) {
+	var policyObj *finopsv1alpha1.EnforcementPolicy
+	kind := policy.KindEnforcementPolicy
+	switch p := obj.(type) {
+	case *finopsv1alpha1.EnforcementPolicy:
+		policyObj = p
+	case *finopsv1alpha1.ClusterEnforcementPolicy:
+		// Validation only reads the spec, so a view is enough
+		policyObj = &finopsv1alpha1.EnforcementPolicy{ObjectMeta: p.ObjectMeta, Spec: p.Spec}
+		kind = policy.KindClusterEnforcementPolicy
+	default:
+		return nil, fmt.Errorf("expected an EnforcementPolicy or ClusterEnforcementPolicy, got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	if kind == policy.KindEnforcementPolicy {
+		warnings = append(warnings, policy.OwnNamespaceWarnings(policyObj)...)
+	}
+	warnings = append(warnings, policy.ProtectedNamespaceWarnings(policyObj, w.ProtectedNamespaces)...)
+	errs := policy.ValidateSpec(policyObj)
+	_, expressionErrs := policy.CompileExpressions(&policyObj.Spec)
+	if errs = append(errs, expressionErrs...); len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(
+			finopsv1alpha1.GroupVersion.WithKind(kind).GroupKind(),
+			policyObj.Name,
+			errs,
+		)
+	}
+
+	return warnings, nil
+}
@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	finopsv1alpha1 "github.com/yourusername/finops-enforcer/api/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestPolicyWebhook(t *testing.T) {
+	w := &PolicyWebhook{ProtectedNamespaces: []string{"kube-system"}}
+	newPolicy := func(namespace, include string, minHourlyCost float64) *finopsv1alpha1.EnforcementPolicy {
+		return &finopsv1alpha1.EnforcementPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "idle", Namespace: namespace},
+			Spec: finopsv1alpha1.EnforcementPolicySpec{
+				Scope: finopsv1alpha1.ScopeSpec{
+					Namespaces: finopsv1alpha1.NamespaceFilter{Include: []string{include}},
+				},
+				Conditions: finopsv1alpha1.ConditionsSpec{
+					IdleWindow:    metav1.Duration{Duration: time.Hour},
+					MinHourlyCost: minHourlyCost,
+				},
+				Actions: finopsv1alpha1.ActionsSpec{Type: finopsv1alpha1.ActionTypeScaleToZero},
+			},
+		}
+	}
+
+	invalidExpression := newPolicy("dev-a", "dev-*", 1)
+	invalidExpression.Spec.Conditions.Expression = `cost.hourlyCost >`
+	misspelledField := newPolicy("dev-a", "dev-*", 1)
+	misspelledField.Spec.Scope.Expression = `object.spec.replica > 1`
+
+	tests := []struct {
+		name         string
+		policy       *finopsv1alpha1.EnforcementPolicy
+		wantWarnings int
+		wantInvalid  bool
+	}{
+		{name: "valid", policy: newPolicy("dev-a", "dev-*", 1)},
+		{name: "protected namespace", policy: newPolicy("kube-system", "kube-*", 1), wantWarnings: 1},
+		{name: "scope misses own namespace", policy: newPolicy("finops-system", "dev-*", 1), wantWarnings: 1},
+		{name: "invalid", policy: newPolicy("dev-a", "dev-*", -1), wantInvalid: true},
+		{name: "invalid expression", policy: invalidExpression, wantInvalid: true},
+		{name: "misspelled expression field", policy: misspelledField, wantInvalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := w.Default(admissionContext(admissionv1.Create), tt.policy); err != nil {
+				t.Fatalf("Default() error = %v", err)
+			}
+			if !tt.policy.Spec.Enforcement.IsDryRun() {
+				t.Error("Default() did not default dryRun to true")
+			}
+
+			warnings, err := w.ValidateCreate(context.Background(), tt.policy)
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("ValidateCreate() warnings = %v, want %d", warnings, tt.wantWarnings)
+			}
+			if apierrors.IsInvalid(err) != tt.wantInvalid {
+				t.Errorf("ValidateCreate() error = %v, wantInvalid %v", err, tt.wantInvalid)
+			}
+		})
+	}
+}
+
+// admissionContext returns a context carrying an admission request for operation
+func admissionContext(operation admissionv1.Operation) context.Context {
+	return admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{Operation: operation},
+	})
+}
+
+func TestPolicyWebhookDefault(t *testing.T) {
+	w := &PolicyWebhook{}
+	dryRun, enforce := true, false
+
+	tests := []struct {
+		name       string
+		operation  admissionv1.Operation
+		dryRun     *bool
+		wantDryRun *bool
+	}{
+		{name: "create defaults to dry-run", operation: admissionv1.Create, wantDryRun: &dryRun},
+		{name: "create keeps explicit value", operation: admissionv1.Create, dryRun: &enforce, wantDryRun: &enforce},
+		{name: "update leaves unset", operation: admissionv1.Update},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &finopsv1alpha1.ClusterEnforcementPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "idle"},
+				Spec: finopsv1alpha1.EnforcementPolicySpec{
+					Enforcement: finopsv1alpha1.EnforcementSpec{DryRun: tt.dryRun},
+				},
+			}
+			if err := w.Default(admissionContext(tt.operation), p); err != nil {
+				t.Fatalf("Default() error = %v", err)
+			}
+			if !reflect.DeepEqual(p.Spec.Enforcement.DryRun, tt.wantDryRun) {
+				t.Errorf("DryRun = %v, want %v", p.Spec.Enforcement.DryRun, tt.wantDryRun)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Kind identifies the type of a workload managed by FinOps Enforcer
+type Kind string
+
+const (
+	KindDeployment  Kind = "Deployment"
+	KindStatefulSet Kind = "StatefulSet"
+	KindCronJob     Kind = "CronJob"
+)
+
+// SupportedKinds lists all workload kinds that can be enforced
+var SupportedKinds = []Kind{KindDeployment, KindStatefulSet, KindCronJob}
+
+// Scalable reports whether workloads of this kind are paused by scaling replicas
+func (k Kind) Scalable() bool {
+	return k == KindDeployment || k == KindStatefulSet
+}
+
+// Suspendable reports whether workloads of this kind are paused via spec.suspend
+func (k Kind) Suspendable() bool {
+	return k == KindCronJob
+}
+
+// Workload wraps an enforceable Kubernetes object so that the policy engine,
+// executor, notifier and cost lookup can treat all kinds uniformly
+type Workload struct {
+	client.Object
+	Kind Kind
+}
+
+// FromDeployment wraps a Deployment as a Workload
+func FromDeployment(deployment *appsv1.Deployment) *Workload {
+	return &Workload{Object: deployment, Kind: KindDeployment}
+}
+
+// FromStatefulSet wraps a StatefulSet as a Workload
+func FromStatefulSet(statefulSet *appsv1.StatefulSet) *Workload {
+	return &Workload{Object: statefulSet, Kind: KindStatefulSet}
+}
+
+// FromCronJob wraps a CronJob as a Workload
+func FromCronJob(cronJob *batchv1.CronJob) *Workload {
+	return &Workload{Object: cronJob, Kind: KindCronJob}
+}
+
+// New returns an empty Workload of the given kind, suitable for client.Get
+func New(kind Kind) (*Workload, error) {
+	switch kind {
+	case KindDeployment:
+		return FromDeployment(&appsv1.Deployment{}), nil
+	case KindStatefulSet:
+		return FromStatefulSet(&appsv1.StatefulSet{}), nil
+	case KindCronJob:
+		return FromCronJob(&batchv1.CronJob{}), nil
+	default:
+		return nil, fmt.Errorf("unsupported workload kind: %s", kind)
+	}
+}
+
+// Replicas returns the desired replica count (defaults to 1 when unset,
+// always 0 for kinds that are not scalable)
+func (w *Workload) Replicas() int32 {
+	var replicas *int32
+	switch obj := w.Object.(type) {
+	case *appsv1.Deployment:
+		replicas = obj.Spec.Replicas
+	case *appsv1.StatefulSet:
+		replicas = obj.Spec.Replicas
+	default:
+		return 0
+	}
+
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
+
+// SetReplicas sets the desired replica count
+func (w *Workload) SetReplicas(replicas int32) {
+	switch obj := w.Object.(type) {
+	case *appsv1.Deployment:
+		obj.Spec.Replicas = &replicas
+	case *appsv1.StatefulSet:
+		obj.Spec.Replicas = &replicas
+	}
+}
+
+// Suspended reports whether a suspendable workload has spec.suspend set
+func (w *Workload) Suspended() bool {
+	if cronJob, ok := w.Object.(*batchv1.CronJob); ok {
+		return cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+	}
+
+	return false
+}
+
+// SetSuspended sets spec.suspend on suspendable workloads
+func (w *Workload) SetSuspended(suspend bool) {
+	if cronJob, ok := w.Object.(*batchv1.CronJob); ok {
+		cronJob.Spec.Suspend = &suspend
+	}
+}
+
+// Key returns the identifying key of the workload
+func (w *Workload) Key() Key {
+	return Key{
+		Kind:      w.Kind,
+		Namespace: w.GetNamespace(),
+		Name:      w.GetName(),
+	}
+}
+
+// Key identifies a workload by kind, namespace and name
+type Key struct {
+	Kind      Kind
+	Namespace string
+	Name      string
+}
+
+// String formats the key as "namespace/name" for Deployments and
+// "kind/namespace/name" (lowercase kind) for all other kinds
+func (k Key) String() string {
+	if k.Kind == KindDeployment || k.Kind == "" {
+		return fmt.Sprintf("%s/%s", k.Namespace, k.Name)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", strings.ToLower(string(k.Kind)), k.Namespace, k.Name)
+}
+
+// ParseKey parses a key produced by Key.String
+func ParseKey(s string) (Key, error) {
+	parts := strings.Split(s, "/")
+	switch len(parts) {
+	case 2:
+		if parts[0] == "" || parts[1] == "" {
+			return Key{}, fmt.Errorf("invalid workload key: %q", s)
+		}
+		return Key{Kind: KindDeployment, Namespace: parts[0], Name: parts[1]}, nil
+	case 3:
+		kind, err := ParseKind(parts[0])
+		if err != nil {
+			return Key{}, err
+		}
+		if parts[1] == "" || parts[2] == "" {
+			return Key{}, fmt.Errorf("invalid workload key: %q", s)
+		}
+		return Key{Kind: kind, Namespace: parts[1], Name: parts[2]}, nil
+	default:
+		return Key{}, fmt.Errorf("invalid workload key: %q", s)
+	}
+}
+
+// ParseKind parses a kind name case-insensitively
+func ParseKind(s string) (Kind, error) {
+	for _, kind := range SupportedKinds {
+		if strings.EqualFold(string(kind), s) {
+			return kind, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported workload kind: %s", s)
+}
+
+// List returns all workloads of the given kind matching the list options
+func List(ctx context.Context, c client.Reader, kind Kind, opts ...client.ListOption) ([]*Workload, error) {
+	workloads := []*Workload{}
+	switch kind {
+	case KindDeployment:
+		list := &appsv1.DeploymentList{}
+		if err := c.List(ctx, list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list deployments: %w", err)
+		}
+		for i := range list.Items {
+			workloads = append(workloads, FromDeployment(&list.Items[i]))
+		}
+	case KindStatefulSet:
+		list := &appsv1.StatefulSetList{}
+		if err := c.List(ctx, list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+		}
+		for i := range list.Items {
+			workloads = append(workloads, FromStatefulSet(&list.Items[i]))
+		}
+	case KindCronJob:
+		list := &batchv1.CronJobList{}
+		if err := c.List(ctx, list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+		}
+		for i := range list.Items {
+			workloads = append(workloads, FromCronJob(&list.Items[i]))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported workload kind: %s", kind)
+	}
+
+	return workloads, nil
+}
+
+// Get fetches a single workload by key
+func Get(ctx context.Context, c client.Reader, key Key) (*Workload, error) {
+	w, err := New(key.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Get(ctx, client.ObjectKey{
+		Namespace: key.Namespace,
+		Name:      key.Name,
+	}, w.Object); err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", strings.ToLower(string(key.Kind)), err)
+	}
+
+	return w, nil
+}
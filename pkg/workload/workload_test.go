@@ -0,0 +1,75 @@
+package workload
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeyRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		key  Key
+		want string
+	}{
+		{
+			name: "deployment",
+			key:  Key{Kind: KindDeployment, Namespace: "dev", Name: "api"},
+			want: "dev/api",
+		},
+		{
+			name: "statefulset",
+			key:  Key{Kind: KindStatefulSet, Namespace: "dev", Name: "postgres"},
+			want: "statefulset/dev/postgres",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.key.String()
+			if got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+
+			parsed, err := ParseKey(got)
+			if err != nil {
+				t.Fatalf("ParseKey() error = %v", err)
+			}
+			if parsed != tt.key {
+				t.Errorf("ParseKey() = %v, want %v", parsed, tt.key)
+			}
+		})
+	}
+}
+
+func TestParseKeyInvalid(t *testing.T) {
+	for _, s := range []string{"", "dev", "dev/", "daemonset/dev/x", "a/b/c/d"} {
+		if _, err := ParseKey(s); err == nil {
+			t.Errorf("ParseKey(%q) expected error", s)
+		}
+	}
+}
+
+func TestReplicas(t *testing.T) {
+	three := int32(3)
+	sts := FromStatefulSet(&appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "dev"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &three},
+	})
+
+	if got := sts.Replicas(); got != 3 {
+		t.Errorf("Replicas() = %v, want 3", got)
+	}
+
+	sts.SetReplicas(0)
+	if got := sts.Replicas(); got != 0 {
+		t.Errorf("Replicas() after SetReplicas(0) = %v, want 0", got)
+	}
+
+	// Unset replicas default to 1
+	deployment := FromDeployment(&appsv1.Deployment{})
+	if got := deployment.Replicas(); got != 1 {
+		t.Errorf("Replicas() with nil spec = %v, want 1", got)
+	}
+}